@@ -0,0 +1,163 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains functions which build the URLs of images on the CDN.
+
+package discordgo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ImageFormat is the format of an image on the CDN.
+type ImageFormat string
+
+// Block contains the known ImageFormat values
+const (
+	ImageFormatPNG  ImageFormat = "png"
+	ImageFormatJPEG ImageFormat = "jpg"
+	ImageFormatWebP ImageFormat = "webp"
+	ImageFormatGIF  ImageFormat = "gif"
+)
+
+// Limits of the sizes of images on the CDN, sizes have to be powers of two between them.
+const (
+	ImageSizeMin = 16
+	ImageSizeMax = 4096
+)
+
+// Errors returned when building a URL of an image fails.
+var (
+	ErrImageNoHash = errors.New("image hash is empty")
+	ErrImageFormat = errors.New("invalid image format")
+	ErrImageSize   = errors.New("invalid image size")
+)
+
+// cdnImage returns the URL of an image identified by a hash, base is the URL of its directory.
+// Hashes of animated images start with "a_".
+func cdnImage(base, hash string, format ImageFormat, size int) (string, error) {
+	if hash == "" {
+		return "", ErrImageNoHash
+	}
+	return cdnURL(base+hash, strings.HasPrefix(hash, "a_"), format, size)
+}
+
+// cdnURL validates the format and size and returns the URL of the image, which
+// is URL with the extension of the format.
+// format : The format, or empty for GIF if the image is animated and else PNG.
+// size   : The size, or 0 for the default size.
+func cdnURL(URL string, animated bool, format ImageFormat, size int) (string, error) {
+	switch format {
+	case "":
+		format = ImageFormatPNG
+		if animated {
+			format = ImageFormatGIF
+		}
+	case ImageFormatPNG, ImageFormatJPEG, ImageFormatWebP:
+	case ImageFormatGIF:
+		if !animated {
+			return "", fmt.Errorf("%w: %s is only available for animated images", ErrImageFormat, format)
+		}
+	default:
+		return "", fmt.Errorf("%w: %q", ErrImageFormat, format)
+	}
+
+	URL += "." + string(format)
+	if size == 0 {
+		return URL, nil
+	}
+	if size < ImageSizeMin || size > ImageSizeMax || size&(size-1) != 0 {
+		return "", fmt.Errorf("%w: %d isn't a power of two between %d and %d", ErrImageSize, size, ImageSizeMin, ImageSizeMax)
+	}
+	return URL + "?size=" + strconv.Itoa(size), nil
+}
+
+// CDNUserAvatar returns the URL of the avatar of a user.
+// userID : The ID of the user.
+// hash   : The hash of the avatar, User.Avatar.
+// format : The format of the image, empty for GIF if the avatar is animated and else PNG.
+// size   : The size of the image, a power of two from 16 to 4096, or 0 for the default size.
+//
+// The other CDN functions take the same format and size.
+func CDNUserAvatar(userID, hash string, format ImageFormat, size int) (string, error) {
+	return cdnImage(EndpointCDNAvatars+userID+"/", hash, format, size)
+}
+
+// CDNMemberAvatar returns the URL of the guild specific avatar of a member.
+// hash : The hash of the avatar of the member.
+func CDNMemberAvatar(guildID, userID, hash string, format ImageFormat, size int) (string, error) {
+	return cdnImage(EndpointCDNGuilds+guildID+"/users/"+userID+"/avatars/", hash, format, size)
+}
+
+// CDNUserBanner returns the URL of the banner of a user.
+func CDNUserBanner(userID, hash string, format ImageFormat, size int) (string, error) {
+	return cdnImage(EndpointCDNBanners+userID+"/", hash, format, size)
+}
+
+// CDNGuildIcon returns the URL of the icon of a guild.
+func CDNGuildIcon(guildID, hash string, format ImageFormat, size int) (string, error) {
+	return cdnImage(EndpointCDNIcons+guildID+"/", hash, format, size)
+}
+
+// CDNGuildSplash returns the URL of the invite splash of a guild.
+func CDNGuildSplash(guildID, hash string, format ImageFormat, size int) (string, error) {
+	return cdnImage(EndpointCDNSplashes+guildID+"/", hash, format, size)
+}
+
+// CDNGuildDiscoverySplash returns the URL of the discovery splash of a guild.
+func CDNGuildDiscoverySplash(guildID, hash string, format ImageFormat, size int) (string, error) {
+	return cdnImage(EndpointCDNDiscoverySplashes+guildID+"/", hash, format, size)
+}
+
+// CDNGuildBanner returns the URL of the banner of a guild.
+func CDNGuildBanner(guildID, hash string, format ImageFormat, size int) (string, error) {
+	return cdnImage(EndpointCDNBanners+guildID+"/", hash, format, size)
+}
+
+// CDNRoleIcon returns the URL of the icon of a role.
+func CDNRoleIcon(roleID, hash string, format ImageFormat, size int) (string, error) {
+	return cdnImage(EndpointCDNRoleIcons+roleID+"/", hash, format, size)
+}
+
+// CDNApplicationIcon returns the URL of the icon of an application.
+func CDNApplicationIcon(applicationID, hash string, format ImageFormat, size int) (string, error) {
+	return cdnImage(EndpointCDNAppIcons+applicationID+"/", hash, format, size)
+}
+
+// CDNEmoji returns the URL of a custom emoji.
+// animated : Whether the emoji is animated, Emoji.Animated.
+func CDNEmoji(emojiID string, animated bool, format ImageFormat, size int) (string, error) {
+	if emojiID == "" {
+		return "", ErrImageNoHash
+	}
+	return cdnURL(EndpointCDNEmojis+emojiID, animated, format, size)
+}
+
+// CDNSticker returns the URL of a sticker. The format of the image is given
+// by the format of the sticker, Lottie stickers are JSON files without a size.
+// formatType : The format of the sticker, Sticker.FormatType.
+func CDNSticker(stickerID string, formatType StickerFormatType, size int) (string, error) {
+	if stickerID == "" {
+		return "", ErrImageNoHash
+	}
+
+	switch formatType {
+	case StickerFormatTypePNG, StickerFormatTypeAPNG:
+		return cdnURL(EndpointCDNStickers+stickerID, false, ImageFormatPNG, size)
+	case StickerFormatTypeGIF:
+		return cdnURL(EndpointCDNStickers+stickerID, true, ImageFormatGIF, size)
+	case StickerFormatTypeLOTTIE:
+		if size != 0 {
+			return "", fmt.Errorf("%w: Lottie stickers have no size", ErrImageSize)
+		}
+		return EndpointCDNStickers + stickerID + ".json", nil
+	}
+	return "", fmt.Errorf("%w: unknown sticker format %d", ErrImageFormat, formatType)
+}
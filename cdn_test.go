@@ -0,0 +1,38 @@
+package discordgo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCDN(t *testing.T) {
+	tests := []struct {
+		url  func() (string, error)
+		want string
+		err  error
+	}{
+		{func() (string, error) { return CDNUserAvatar("1", "abc", "", 0) }, "https://cdn.discordapp.com/avatars/1/abc.png", nil},
+		{func() (string, error) { return CDNUserAvatar("1", "a_abc", "", 128) }, "https://cdn.discordapp.com/avatars/1/a_abc.gif?size=128", nil},
+		{func() (string, error) { return CDNUserAvatar("1", "a_abc", ImageFormatWebP, 4096) }, "https://cdn.discordapp.com/avatars/1/a_abc.webp?size=4096", nil},
+		{func() (string, error) { return CDNMemberAvatar("2", "1", "abc", ImageFormatJPEG, 16) }, "https://cdn.discordapp.com/guilds/2/users/1/avatars/abc.jpg?size=16", nil},
+		{func() (string, error) { return CDNGuildDiscoverySplash("2", "abc", "", 0) }, "https://cdn.discordapp.com/discovery-splashes/2/abc.png", nil},
+		{func() (string, error) { return CDNRoleIcon("3", "abc", "", 0) }, "https://cdn.discordapp.com/role-icons/3/abc.png", nil},
+		{func() (string, error) { return CDNApplicationIcon("4", "abc", "", 0) }, "https://cdn.discordapp.com/app-icons/4/abc.png", nil},
+		{func() (string, error) { return CDNEmoji("5", true, "", 0) }, "https://cdn.discordapp.com/emojis/5.gif", nil},
+		{func() (string, error) { return CDNSticker("6", StickerFormatTypeAPNG, 0) }, "https://cdn.discordapp.com/stickers/6.png", nil},
+		{func() (string, error) { return CDNSticker("6", StickerFormatTypeLOTTIE, 0) }, "https://cdn.discordapp.com/stickers/6.json", nil},
+		{func() (string, error) { return CDNGuildIcon("2", "abc", ImageFormatGIF, 0) }, "", ErrImageFormat},
+		{func() (string, error) { return CDNGuildIcon("2", "abc", "bmp", 0) }, "", ErrImageFormat},
+		{func() (string, error) { return CDNGuildBanner("2", "abc", "", 100) }, "", ErrImageSize},
+		{func() (string, error) { return CDNGuildBanner("2", "abc", "", 8192) }, "", ErrImageSize},
+		{func() (string, error) { return CDNGuildSplash("2", "", "", 0) }, "", ErrImageNoHash},
+		{func() (string, error) { return CDNSticker("6", StickerFormatTypeLOTTIE, 64) }, "", ErrImageSize},
+	}
+
+	for i, test := range tests {
+		got, err := test.url()
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("%d: got %q, %v, want %q, %v", i, got, err, test.want, test.err)
+		}
+	}
+}
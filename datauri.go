@@ -0,0 +1,231 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains helpers which encode images as data URIs, the form in
+// which avatars, emojis and icons are uploaded, e.g. with GuildEmojiCreate,
+// WebhookCreate, UserUpdate and GuildEdit.
+
+package discordgo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // For GIF decoding
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+)
+
+// Limits of the size of uploaded images, in bytes.
+const (
+	ImageLimitEmoji    = 256 * 1024
+	ImageLimitRoleIcon = 256 * 1024
+	ImageLimitAvatar   = 10 * 1024 * 1024
+	ImageLimitIcon     = 10 * 1024 * 1024
+)
+
+// Errors returned when encoding an image as a data URI fails.
+var (
+	ErrImageType     = errors.New("unsupported image type, expected PNG, JPEG, GIF or WebP")
+	ErrImageTooLarge = errors.New("image exceeds the size limit")
+)
+
+// DataURIOptions are options of ImageDataURI and ImageDataURIFromImage.
+// Re-encoded images are PNGs, or JPEGs if they were JPEGs. GIFs lose their
+// animation and WebP images can't be re-encoded.
+type DataURIOptions struct {
+	// Limit is the maximum size of the image in bytes, e.g. ImageLimitEmoji.
+	// 0 means no limit.
+	Limit int
+
+	// MaxDimension is the maximum width and height of the image in pixels.
+	// Larger images are downscaled, keeping their aspect ratio. 0 keeps the size.
+	MaxDimension int
+
+	// Shrink makes images which exceed the Limit be re-encoded and
+	// downscaled until they fit, instead of returning ErrImageTooLarge.
+	Shrink bool
+}
+
+// minShrinkDimension is the size below which images aren't shrunk any further.
+const minShrinkDimension = 16
+
+// ImageDataURI reads an image and returns it as a data URI, e.g.
+// "data:image/png;base64,...". The type of the image is sniffed from its
+// content, PNG, JPEG, GIF and WebP images are supported. Images which are
+// within the limits of the options are passed through unchanged.
+// opts : The options, may be nil.
+//
+//	f, _ := os.Open("emoji.png")
+//	uri, err := discordgo.ImageDataURI(f, &discordgo.DataURIOptions{Limit: discordgo.ImageLimitEmoji, Shrink: true})
+//	emoji, err := s.GuildEmojiCreate(guildID, "name", uri, nil)
+func ImageDataURI(r io.Reader, opts *DataURIOptions) (string, error) {
+	if opts == nil {
+		opts = &DataURIOptions{}
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	contentType := sniffImageType(data)
+	if contentType == "" {
+		return "", ErrImageType
+	}
+
+	resize := false
+	if opts.MaxDimension > 0 {
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return "", fmt.Errorf("decoding image: %w", err)
+		}
+		resize = config.Width > opts.MaxDimension || config.Height > opts.MaxDimension
+	}
+	tooLarge := opts.Limit > 0 && len(data) > opts.Limit
+
+	if !resize && !tooLarge {
+		return dataURI(contentType, data), nil
+	}
+	if tooLarge && !opts.Shrink && !resize {
+		return "", fmt.Errorf("%w: %d bytes, the limit is %d", ErrImageTooLarge, len(data), opts.Limit)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("decoding image: %w", err)
+	}
+	return encodeDataURI(img, contentType == "image/jpeg", opts)
+}
+
+// ImageDataURIFromImage encodes an image as a PNG data URI.
+// opts : The options, may be nil.
+func ImageDataURIFromImage(img image.Image, opts *DataURIOptions) (string, error) {
+	if opts == nil {
+		opts = &DataURIOptions{}
+	}
+	return encodeDataURI(img, false, opts)
+}
+
+// encodeDataURI encodes an image as PNG, or JPEG for photos, applying the
+// dimension and size limits of opts.
+func encodeDataURI(img image.Image, photo bool, opts *DataURIOptions) (string, error) {
+	if opts.MaxDimension > 0 {
+		img = fitImage(img, opts.MaxDimension)
+	}
+
+	for {
+		contentType, data, err := encodeImage(img, photo)
+		if err != nil {
+			return "", err
+		}
+		if opts.Limit <= 0 || len(data) <= opts.Limit {
+			return dataURI(contentType, data), nil
+		}
+
+		b := img.Bounds()
+		if !opts.Shrink || b.Dx() <= minShrinkDimension || b.Dy() <= minShrinkDimension {
+			return "", fmt.Errorf("%w: %d bytes, the limit is %d", ErrImageTooLarge, len(data), opts.Limit)
+		}
+		img = scaleImage(img, b.Dx()*3/4, b.Dy()*3/4)
+	}
+}
+
+// encodeImage encodes an image as JPEG if photo is set, or else as PNG.
+func encodeImage(img image.Image, photo bool) (contentType string, data []byte, err error) {
+	var buf bytes.Buffer
+	if photo {
+		contentType = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	} else {
+		contentType = "image/png"
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, img)
+	}
+	return contentType, buf.Bytes(), err
+}
+
+// dataURI returns data as a base64 encoded data URI.
+func dataURI(contentType string, data []byte) string {
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// sniffImageType returns the content type of an image from its magic bytes,
+// or an empty string if it isn't a supported image.
+func sniffImageType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return "image/png"
+	case bytes.HasPrefix(data, []byte("\xff\xd8\xff")):
+		return "image/jpeg"
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return "image/gif"
+	case len(data) >= 12 && bytes.Equal(data[:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")):
+		return "image/webp"
+	}
+	return ""
+}
+
+// fitImage downscales an image to fit into a square of max pixels.
+func fitImage(img image.Image, max int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= max && h <= max {
+		return img
+	}
+
+	if w > h {
+		w, h = max, h*max/w
+	} else {
+		w, h = w*max/h, max
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return scaleImage(img, w, h)
+}
+
+// scaleImage downscales an image by averaging the pixels of the source
+// which make up a pixel of the result.
+func scaleImage(src image.Image, width, height int) image.Image {
+	b := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := b.Min.Y + y*b.Dy()/height
+		y1 := b.Min.Y + (y+1)*b.Dy()/height
+		if y1 == y0 {
+			y1++
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := b.Min.X + x*b.Dx()/width
+			x1 := b.Min.X + (x+1)*b.Dx()/width
+			if x1 == x0 {
+				x1++
+			}
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,111 @@
+package discordgo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// decodeDataURI decodes a PNG data URI.
+func decodeDataURI(t *testing.T, uri string) image.Image {
+	t.Helper()
+
+	if !strings.HasPrefix(uri, "data:image/png;base64,") {
+		t.Fatalf("unexpected data URI %.40s", uri)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(uri, "data:image/png;base64,"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+// noiseImage returns an image which doesn't compress well.
+func noiseImage(width, height int) image.Image {
+	rnd := rand.New(rand.NewSource(1))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(rnd.Intn(256))
+	}
+	return img
+}
+
+func TestImageDataURI(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 32))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	uri, err := ImageDataURI(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uri != "data:image/png;base64,"+base64.StdEncoding.EncodeToString(buf.Bytes()) {
+		t.Errorf("expected the image to be passed through unchanged")
+	}
+
+	uri, err = ImageDataURI(bytes.NewReader(buf.Bytes()), &DataURIOptions{MaxDimension: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := decodeDataURI(t, uri).Bounds(); b.Dx() != 16 || b.Dy() != 8 {
+		t.Errorf("expected the image to be downscaled to 16x8, got %v", b)
+	}
+
+	if _, err = ImageDataURI(strings.NewReader("not an image"), nil); err != ErrImageType {
+		t.Errorf("expected ErrImageType, got %v", err)
+	}
+
+	gif := []byte("GIF89a")
+	if uri, err = ImageDataURI(bytes.NewReader(gif), nil); err != nil || !strings.HasPrefix(uri, "data:image/gif;base64,") {
+		t.Errorf("expected a GIF data URI, got %q, %v", uri, err)
+	}
+}
+
+func TestImageDataURILimit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, noiseImage(128, 128)); err != nil {
+		t.Fatal(err)
+	}
+
+	limit := buf.Len() / 4
+	_, err := ImageDataURI(bytes.NewReader(buf.Bytes()), &DataURIOptions{Limit: limit})
+	if !errors.Is(err, ErrImageTooLarge) {
+		t.Fatalf("expected ErrImageTooLarge, got %v", err)
+	}
+
+	uri, err := ImageDataURI(bytes.NewReader(buf.Bytes()), &DataURIOptions{Limit: limit, Shrink: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := decodeDataURI(t, uri)
+	if img.Bounds().Dx() >= 128 {
+		t.Errorf("expected the image to be shrunk, got %v", img.Bounds())
+	}
+}
+
+func TestImageDataURIFromImage(t *testing.T) {
+	uri, err := ImageDataURIFromImage(noiseImage(40, 20), &DataURIOptions{MaxDimension: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := decodeDataURI(t, uri).Bounds(); b.Dx() != 20 || b.Dy() != 10 {
+		t.Errorf("expected the image to be downscaled to 20x10, got %v", b)
+	}
+
+	if _, err = ImageDataURIFromImage(noiseImage(16, 16), &DataURIOptions{Limit: 10, Shrink: true}); !errors.Is(err, ErrImageTooLarge) {
+		t.Errorf("expected images to not be shrunk below 16 pixels, got %v", err)
+	}
+}
@@ -57,6 +57,8 @@ func New(args ...interface{}) (s *Session, err error) {
 		ShardID:                0,
 		ShardCount:             1,
 		MaxRestRetries:         3,
+		RetryPolicy:            NewRetryPolicy(),
+		InvalidRequests:        NewInvalidRequestLimiter(),
 		Client:                 &http.Client{Timeout: (20 * time.Second)},
 		UserAgent:              "DiscordBot (https://github.com/bwmarrin/discordgo, v" + VERSION + ")",
 		sequence:               new(int64),
@@ -0,0 +1,334 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains a builder for embeds and the validation of embed limits.
+
+package discordgo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits of embeds, in characters.
+const (
+	EmbedLimitTitle       = 256
+	EmbedLimitDescription = 4096
+	EmbedLimitFields      = 25
+	EmbedLimitFieldName   = 256
+	EmbedLimitFieldValue  = 1024
+	EmbedLimitFooterText  = 2048
+	EmbedLimitAuthorName  = 256
+
+	// EmbedLimitTotal is the limit of all titles, descriptions, field names
+	// and values, footer texts and author names of all embeds of a message.
+	EmbedLimitTotal = 6000
+
+	// EmbedLimitCount is the maximum number of embeds of a message.
+	EmbedLimitCount = 10
+)
+
+// ErrEmbedLimit matches all EmbedLimitErrors with errors.Is.
+var ErrEmbedLimit = errors.New("embeds exceed a limit of Discord")
+
+// An EmbedLimitViolation is a part of an embed which exceeds a limit.
+type EmbedLimitViolation struct {
+	// Embed is the index of the embed in the message, -1 for limits of the whole message.
+	Embed int
+
+	// Field is the path of the part which is too long, e.g. "title",
+	// "fields[3].value", "fields", "total" or "embeds".
+	Field string
+
+	// Length is the number of characters or items, Limit the maximum.
+	Length int
+	Limit  int
+}
+
+func (v EmbedLimitViolation) String() string {
+	if v.Embed < 0 {
+		return fmt.Sprintf("%s: %d exceeds the limit of %d", v.Field, v.Length, v.Limit)
+	}
+	return fmt.Sprintf("embed %d: %s: %d exceeds the limit of %d", v.Embed, v.Field, v.Length, v.Limit)
+}
+
+// EmbedLimitError is returned when embeds exceed the limits of Discord.
+// It is returned before a request is sent.
+type EmbedLimitError struct {
+	Violations []EmbedLimitViolation
+}
+
+// Error implements the error interface.
+func (e *EmbedLimitError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.String()
+	}
+	return "embeds exceed the limits of Discord: " + strings.Join(parts, "; ")
+}
+
+// Is makes errors.Is(err, ErrEmbedLimit) true.
+func (e *EmbedLimitError) Is(target error) bool {
+	return target == ErrEmbedLimit
+}
+
+// Validate checks the embed against the limits of Discord and returns an
+// *EmbedLimitError if it exceeds any of them.
+func (e *MessageEmbed) Validate() error {
+	violations, _ := e.violations(0)
+	if len(violations) > 0 {
+		return &EmbedLimitError{Violations: violations}
+	}
+	return nil
+}
+
+// ValidateEmbeds checks the embeds of a message against the limits of Discord,
+// including the limits of all embeds together. It returns an *EmbedLimitError
+// if they exceed any of them. Nil embeds are ignored.
+func ValidateEmbeds(embeds []*MessageEmbed) error {
+	var violations []EmbedLimitViolation
+	if len(embeds) > EmbedLimitCount {
+		violations = append(violations, EmbedLimitViolation{-1, "embeds", len(embeds), EmbedLimitCount})
+	}
+
+	total := 0
+	for i, e := range embeds {
+		if e == nil {
+			continue
+		}
+		v, length := e.violations(i)
+		violations = append(violations, v...)
+		total += length
+	}
+
+	if len(embeds) > 1 && total > EmbedLimitTotal {
+		violations = append(violations, EmbedLimitViolation{-1, "total", total, EmbedLimitTotal})
+	}
+
+	if len(violations) > 0 {
+		return &EmbedLimitError{Violations: violations}
+	}
+	return nil
+}
+
+// validateEmbedValues is ValidateEmbeds for embeds which aren't pointers.
+func validateEmbedValues(embeds *[]MessageEmbed) error {
+	if embeds == nil {
+		return nil
+	}
+
+	ptrs := make([]*MessageEmbed, len(*embeds))
+	for i := range *embeds {
+		ptrs[i] = &(*embeds)[i]
+	}
+	return ValidateEmbeds(ptrs)
+}
+
+// validateEmbeds validates the embeds of the edit, if they are changed.
+func (w *WebhookEdit) validateEmbeds() error {
+	if w.Embeds == nil {
+		return nil
+	}
+	return ValidateEmbeds(*w.Embeds)
+}
+
+// violations returns the limits the embed exceeds and its total length.
+func (e *MessageEmbed) violations(index int) (violations []EmbedLimitViolation, total int) {
+	check := func(field, text string, limit int) {
+		length := utf8.RuneCountInString(text)
+		total += length
+		if length > limit {
+			violations = append(violations, EmbedLimitViolation{index, field, length, limit})
+		}
+	}
+
+	check("title", e.Title, EmbedLimitTitle)
+	check("description", e.Description, EmbedLimitDescription)
+
+	if len(e.Fields) > EmbedLimitFields {
+		violations = append(violations, EmbedLimitViolation{index, "fields", len(e.Fields), EmbedLimitFields})
+	}
+	for i, f := range e.Fields {
+		if f == nil {
+			continue
+		}
+		check(fmt.Sprintf("fields[%d].name", i), f.Name, EmbedLimitFieldName)
+		check(fmt.Sprintf("fields[%d].value", i), f.Value, EmbedLimitFieldValue)
+	}
+
+	if e.Footer != nil {
+		check("footer.text", e.Footer.Text, EmbedLimitFooterText)
+	}
+	if e.Author != nil {
+		check("author.name", e.Author.Name, EmbedLimitAuthorName)
+	}
+
+	if total > EmbedLimitTotal {
+		violations = append(violations, EmbedLimitViolation{index, "total", total, EmbedLimitTotal})
+	}
+	return
+}
+
+// Truncate shortens all texts of the embed to their limits, marking
+// shortened texts with an ellipsis, and removes fields beyond the 25th.
+// If the embed still exceeds the total limit, the description is shortened
+// and then fields are removed from the end.
+func (e *MessageEmbed) Truncate() {
+	e.Title = truncateText(e.Title, EmbedLimitTitle)
+	e.Description = truncateText(e.Description, EmbedLimitDescription)
+
+	if len(e.Fields) > EmbedLimitFields {
+		e.Fields = e.Fields[:EmbedLimitFields]
+	}
+	for _, f := range e.Fields {
+		if f != nil {
+			f.Name = truncateText(f.Name, EmbedLimitFieldName)
+			f.Value = truncateText(f.Value, EmbedLimitFieldValue)
+		}
+	}
+
+	if e.Footer != nil {
+		e.Footer.Text = truncateText(e.Footer.Text, EmbedLimitFooterText)
+	}
+	if e.Author != nil {
+		e.Author.Name = truncateText(e.Author.Name, EmbedLimitAuthorName)
+	}
+
+	_, total := e.violations(0)
+	if excess := total - EmbedLimitTotal; excess > 0 {
+		length := utf8.RuneCountInString(e.Description)
+		if excess < length {
+			e.Description = truncateText(e.Description, length-excess)
+			return
+		}
+		e.Description = ""
+	}
+
+	for len(e.Fields) > 0 {
+		if _, total = e.violations(0); total <= EmbedLimitTotal {
+			break
+		}
+		e.Fields = e.Fields[:len(e.Fields)-1]
+	}
+}
+
+// truncateText shortens text to limit characters, ending it with an ellipsis.
+func truncateText(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	if limit <= 0 {
+		return ""
+	}
+
+	runes := []rune(text)
+	return string(runes[:limit-1]) + "…"
+}
+
+// EmbedBuilder builds a MessageEmbed with chained calls. The limits of
+// Discord are checked by Build.
+//
+//	embed, err := discordgo.NewEmbed().
+//		SetTitle("Report").
+//		AddField("Status", "Open", true).
+//		Build()
+type EmbedBuilder struct {
+	embed    *MessageEmbed
+	truncate bool
+}
+
+// NewEmbed returns a new EmbedBuilder for a rich embed.
+func NewEmbed() *EmbedBuilder {
+	return &EmbedBuilder{embed: &MessageEmbed{Type: "rich"}}
+}
+
+// Truncating makes Build shorten texts which exceed the limits of Discord
+// instead of returning an error, see MessageEmbed.Truncate.
+func (b *EmbedBuilder) Truncating() *EmbedBuilder {
+	b.truncate = true
+	return b
+}
+
+// SetTitle sets the title of the embed.
+func (b *EmbedBuilder) SetTitle(title string) *EmbedBuilder {
+	b.embed.Title = title
+	return b
+}
+
+// SetDescription sets the description of the embed.
+func (b *EmbedBuilder) SetDescription(description string) *EmbedBuilder {
+	b.embed.Description = description
+	return b
+}
+
+// SetURL sets the URL the title links to.
+func (b *EmbedBuilder) SetURL(url string) *EmbedBuilder {
+	b.embed.URL = url
+	return b
+}
+
+// SetColor sets the color of the embed, e.g. 0xff0000 for red.
+func (b *EmbedBuilder) SetColor(color int) *EmbedBuilder {
+	b.embed.Color = color
+	return b
+}
+
+// SetTimestamp sets the time shown in the footer of the embed.
+func (b *EmbedBuilder) SetTimestamp(t time.Time) *EmbedBuilder {
+	b.embed.Timestamp = t.Format(time.RFC3339)
+	return b
+}
+
+// SetFooter sets the footer of the embed.
+// iconURL : The URL of the footer icon, may be empty.
+func (b *EmbedBuilder) SetFooter(text, iconURL string) *EmbedBuilder {
+	b.embed.Footer = &MessageEmbedFooter{Text: text, IconURL: iconURL}
+	return b
+}
+
+// SetAuthor sets the author of the embed.
+// url     : The URL the name links to, may be empty.
+// iconURL : The URL of the author icon, may be empty.
+func (b *EmbedBuilder) SetAuthor(name, url, iconURL string) *EmbedBuilder {
+	b.embed.Author = &MessageEmbedAuthor{Name: name, URL: url, IconURL: iconURL}
+	return b
+}
+
+// SetImage sets the image of the embed.
+func (b *EmbedBuilder) SetImage(url string) *EmbedBuilder {
+	b.embed.Image = &MessageEmbedImage{URL: url}
+	return b
+}
+
+// SetThumbnail sets the thumbnail of the embed.
+func (b *EmbedBuilder) SetThumbnail(url string) *EmbedBuilder {
+	b.embed.Thumbnail = &MessageEmbedThumbnail{URL: url}
+	return b
+}
+
+// AddField adds a field to the embed.
+// inline : Whether the field may be shown next to other inline fields.
+func (b *EmbedBuilder) AddField(name, value string, inline bool) *EmbedBuilder {
+	b.embed.Fields = append(b.embed.Fields, &MessageEmbedField{Name: name, Value: value, Inline: inline})
+	return b
+}
+
+// Build returns the embed. If it exceeds the limits of Discord, an
+// *EmbedLimitError is returned, unless the builder is Truncating.
+func (b *EmbedBuilder) Build() (*MessageEmbed, error) {
+	if b.truncate {
+		b.embed.Truncate()
+	}
+
+	if err := b.embed.Validate(); err != nil {
+		return nil, err
+	}
+	return b.embed, nil
+}
@@ -0,0 +1,103 @@
+package discordgo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEmbedBuilder(t *testing.T) {
+	embed, err := NewEmbed().
+		SetTitle("Report").
+		SetDescription("Everything is fine").
+		SetFooter("footer", "").
+		AddField("Status", "Open", true).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if embed.Type != "rich" || embed.Title != "Report" || len(embed.Fields) != 1 || !embed.Fields[0].Inline {
+		t.Errorf("unexpected embed %+v", embed)
+	}
+}
+
+func TestEmbedBuilderLimits(t *testing.T) {
+	b := NewEmbed().SetTitle(strings.Repeat("t", EmbedLimitTitle+1))
+	for i := 0; i < EmbedLimitFields+1; i++ {
+		b.AddField("name", "value", false)
+	}
+
+	_, err := b.Build()
+	if !errors.Is(err, ErrEmbedLimit) {
+		t.Fatalf("expected an embed limit error, got %v", err)
+	}
+
+	var limitErr *EmbedLimitError
+	if !errors.As(err, &limitErr) || len(limitErr.Violations) != 2 {
+		t.Fatalf("expected 2 violations, got %v", err)
+	}
+	if v := limitErr.Violations[0]; v.Field != "title" || v.Length != EmbedLimitTitle+1 || v.Limit != EmbedLimitTitle {
+		t.Errorf("unexpected violation %+v", v)
+	}
+	if v := limitErr.Violations[1]; v.Field != "fields" || v.Length != EmbedLimitFields+1 {
+		t.Errorf("unexpected violation %+v", v)
+	}
+
+	embed, err := b.Truncating().Build()
+	if err != nil {
+		t.Fatalf("unexpected error after truncating: %v", err)
+	}
+	if utf8.RuneCountInString(embed.Title) != EmbedLimitTitle || !strings.HasSuffix(embed.Title, "…") || len(embed.Fields) != EmbedLimitFields {
+		t.Errorf("embed wasn't truncated: %d characters, %d fields", utf8.RuneCountInString(embed.Title), len(embed.Fields))
+	}
+}
+
+func TestEmbedTruncateTotal(t *testing.T) {
+	embed := &MessageEmbed{Description: strings.Repeat("d", EmbedLimitDescription)}
+	for i := 0; i < 5; i++ {
+		embed.Fields = append(embed.Fields, &MessageEmbedField{Name: "n", Value: strings.Repeat("v", EmbedLimitFieldValue)})
+	}
+
+	if err := embed.Validate(); err == nil {
+		t.Fatal("expected the total to exceed the limit")
+	}
+	embed.Truncate()
+	if err := embed.Validate(); err != nil {
+		t.Errorf("unexpected error after truncating: %v", err)
+	}
+	if len(embed.Fields) != 5 {
+		t.Errorf("expected the description to be shortened before fields are removed")
+	}
+}
+
+func TestValidateEmbeds(t *testing.T) {
+	embeds := make([]*MessageEmbed, EmbedLimitCount+1)
+	err := ValidateEmbeds(embeds)
+	var limitErr *EmbedLimitError
+	if !errors.As(err, &limitErr) || limitErr.Violations[0].Field != "embeds" || limitErr.Violations[0].Embed != -1 {
+		t.Errorf("expected too many embeds, got %v", err)
+	}
+
+	embeds = []*MessageEmbed{
+		{Description: strings.Repeat("a", EmbedLimitDescription)},
+		{Description: strings.Repeat("b", EmbedLimitDescription)},
+	}
+	err = ValidateEmbeds(embeds)
+	if !errors.As(err, &limitErr) || len(limitErr.Violations) != 1 || limitErr.Violations[0].Field != "total" {
+		t.Errorf("expected the total to exceed the limit, got %v", err)
+	}
+}
+
+func TestChannelMessageSendEmbedLimit(t *testing.T) {
+	s, closeServer := newIteratorTestSession(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	defer closeServer()
+
+	_, err := s.ChannelMessageSendEmbed("1", &MessageEmbed{Title: strings.Repeat("t", EmbedLimitTitle+1)})
+	if !errors.Is(err, ErrEmbedLimit) {
+		t.Errorf("expected an embed limit error, got %v", err)
+	}
+}
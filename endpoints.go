@@ -32,13 +32,19 @@ var (
 	EndpointGatewayBot = EndpointGateway + "/bot"
 	EndpointWebhooks   = EndpointAPI + "webhooks/"
 
-	EndpointCDN             = "https://cdn.discordapp.com/"
-	EndpointCDNAttachments  = EndpointCDN + "attachments/"
-	EndpointCDNAvatars      = EndpointCDN + "avatars/"
-	EndpointCDNIcons        = EndpointCDN + "icons/"
-	EndpointCDNSplashes     = EndpointCDN + "splashes/"
-	EndpointCDNChannelIcons = EndpointCDN + "channel-icons/"
-	EndpointCDNBanners      = EndpointCDN + "banners/"
+	EndpointCDN                  = "https://cdn.discordapp.com/"
+	EndpointCDNAttachments       = EndpointCDN + "attachments/"
+	EndpointCDNAvatars           = EndpointCDN + "avatars/"
+	EndpointCDNIcons             = EndpointCDN + "icons/"
+	EndpointCDNSplashes          = EndpointCDN + "splashes/"
+	EndpointCDNDiscoverySplashes = EndpointCDN + "discovery-splashes/"
+	EndpointCDNChannelIcons      = EndpointCDN + "channel-icons/"
+	EndpointCDNBanners           = EndpointCDN + "banners/"
+	EndpointCDNRoleIcons         = EndpointCDN + "role-icons/"
+	EndpointCDNAppIcons          = EndpointCDN + "app-icons/"
+	EndpointCDNEmojis            = EndpointCDN + "emojis/"
+	EndpointCDNStickers          = EndpointCDN + "stickers/"
+	EndpointCDNGuilds            = EndpointCDN + "guilds/"
 
 	EndpointAuth           = EndpointAPI + "auth/"
 	EndpointLogin          = EndpointAuth + "login"
@@ -120,11 +126,18 @@ var (
 	EndpointChannelMessageCrosspost   = func(cID, mID string) string { return EndpointChannel(cID) + "/messages/" + mID + "/crosspost" }
 	EndpointChannelDMRecipient        = func(cID, uID string) string { return EndpointChannels + cID + "/recipients/" + uID }
 
+	EndpointChannelThreadsActive          = func(cID string) string { return EndpointChannel(cID) + "/threads/active" }
+	EndpointChannelThreadsArchivedPublic  = func(cID string) string { return EndpointChannel(cID) + "/threads/archived/public" }
+	EndpointChannelThreadsArchivedPrivate = func(cID string) string { return EndpointChannel(cID) + "/threads/archived/private" }
+
 	EndpointGroupIcon = func(cID, hash string) string { return EndpointCDNChannelIcons + cID + "/" + hash + ".png" }
 
 	EndpointChannelWebhooks = func(cID string) string { return EndpointChannel(cID) + "/webhooks" }
 	EndpointWebhook         = func(wID string) string { return EndpointWebhooks + wID }
 	EndpointWebhookToken    = func(wID, token string) string { return EndpointWebhooks + wID + "/" + token }
+	EndpointWebhookMessage  = func(wID, token, mID string) string { return EndpointWebhooks + wID + "/" + token + "/messages/" + mID }
+	EndpointWebhookSlack    = func(wID, token string) string { return EndpointWebhooks + wID + "/" + token + "/slack" }
+	EndpointWebhookGitHub   = func(wID, token string) string { return EndpointWebhooks + wID + "/" + token + "/github" }
 
 	EndpointMessageReactionsAll = func(cID, mID string) string {
 		return EndpointChannelMessage(cID, mID) + "/reactions"
@@ -146,8 +159,8 @@ var (
 
 	EndpointIntegrationsJoin = func(iID string) string { return EndpointAPI + "integrations/" + iID + "/join" }
 
-	EndpointEmoji         = func(eID string) string { return EndpointAPI + "emojis/" + eID + ".png" }
-	EndpointEmojiAnimated = func(eID string) string { return EndpointAPI + "emojis/" + eID + ".gif" }
+	EndpointEmoji         = func(eID string) string { return EndpointCDNEmojis + eID + ".png" }
+	EndpointEmojiAnimated = func(eID string) string { return EndpointCDNEmojis + eID + ".gif" }
 
 	EndpointOauth2            = EndpointAPI + "oauth2/"
 	EndpointOAuthApplications = EndpointOauth2 + "applications"
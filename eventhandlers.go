@@ -38,6 +38,7 @@ const (
 	guildRoleDeleteEventType            = "GUILD_ROLE_DELETE"
 	guildRoleUpdateEventType            = "GUILD_ROLE_UPDATE"
 	guildUpdateEventType                = "GUILD_UPDATE"
+	invalidRequestLimitEventType        = "__INVALID_REQUEST_LIMIT__"
 	messageAckEventType                 = "MESSAGE_ACK"
 	messageCreateEventType              = "MESSAGE_CREATE"
 	messageDeleteEventType              = "MESSAGE_DELETE"
@@ -51,6 +52,7 @@ const (
 	presencesReplaceEventType           = "PRESENCES_REPLACE"
 	rateLimitEventType                  = "__RATE_LIMIT__"
 	readyEventType                      = "READY"
+	requestRetryEventType               = "__REQUEST_RETRY__"
 	relationshipAddEventType            = "RELATIONSHIP_ADD"
 	relationshipRemoveEventType         = "RELATIONSHIP_REMOVE"
 	resumedEventType                    = "RESUMED"
@@ -475,6 +477,21 @@ func (eh guildUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// invalidRequestLimitEventHandler is an event handler for InvalidRequestLimit events.
+type invalidRequestLimitEventHandler func(*Session, *InvalidRequestLimit)
+
+// Type returns the event type for InvalidRequestLimit events.
+func (eh invalidRequestLimitEventHandler) Type() string {
+	return invalidRequestLimitEventType
+}
+
+// Handle is the handler for InvalidRequestLimit events.
+func (eh invalidRequestLimitEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*InvalidRequestLimit); ok {
+		eh(s, t)
+	}
+}
+
 // messageAckEventHandler is an event handler for MessageAck events.
 type messageAckEventHandler func(*Session, *MessageAck)
 
@@ -690,6 +707,21 @@ func (eh rateLimitEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// requestRetryEventHandler is an event handler for RequestRetry events.
+type requestRetryEventHandler func(*Session, *RequestRetry)
+
+// Type returns the event type for RequestRetry events.
+func (eh requestRetryEventHandler) Type() string {
+	return requestRetryEventType
+}
+
+// Handle is the handler for RequestRetry events.
+func (eh requestRetryEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*RequestRetry); ok {
+		eh(s, t)
+	}
+}
+
 // readyEventHandler is an event handler for Ready events.
 type readyEventHandler func(*Session, *Ready)
 
@@ -1296,6 +1328,8 @@ func handlerForInterface(handler interface{}) EventHandler {
 		return guildRoleUpdateEventHandler(v)
 	case func(*Session, *GuildUpdate):
 		return guildUpdateEventHandler(v)
+	case func(*Session, *InvalidRequestLimit):
+		return invalidRequestLimitEventHandler(v)
 	case func(*Session, *MessageAck):
 		return messageAckEventHandler(v)
 	case func(*Session, *MessageCreate):
@@ -1320,6 +1354,8 @@ func handlerForInterface(handler interface{}) EventHandler {
 		return rateLimitEventHandler(v)
 	case func(*Session, *Ready):
 		return readyEventHandler(v)
+	case func(*Session, *RequestRetry):
+		return requestRetryEventHandler(v)
 	case func(*Session, *RelationshipAdd):
 		return relationshipAddEventHandler(v)
 	case func(*Session, *RelationshipRemove):
@@ -2,6 +2,7 @@ package discordgo
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // This file contains all the possible structs that can be
@@ -24,6 +25,38 @@ type RateLimit struct {
 	URL string
 }
 
+// RequestRetry is the data for a RequestRetry event.
+// This is a synthetic event and is not dispatched by Discord.
+type RequestRetry struct {
+	Method string
+	URL    string
+
+	// Attempt is the number of the upcoming retry, starting at 1.
+	Attempt int
+
+	// StatusCode is the HTTP status of the failed attempt, 0 on transport errors.
+	StatusCode int
+	Err        error
+
+	// Delay is how long the library waits before retrying.
+	Delay time.Duration
+}
+
+// InvalidRequestLimit is the data for an InvalidRequestLimit event, which is
+// dispatched when the session's InvalidRequestLimiter starts rejecting requests.
+// This is a synthetic event and is not dispatched by Discord.
+type InvalidRequestLimit struct {
+	// Count is the number of invalid requests within Window.
+	Count  int
+	Window time.Duration
+
+	// RetryAfter is how long requests will be rejected for.
+	RetryAfter time.Duration
+
+	// URL is the request which opened the limiter.
+	URL string
+}
+
 // Event provides a basic initial struct for all websocket events.
 type Event struct {
 	Operation int             `json:"op"`
@@ -60,11 +93,15 @@ type ChannelCreate struct {
 // ChannelUpdate is the data for a ChannelUpdate event.
 type ChannelUpdate struct {
 	*Channel
+	// BeforeUpdate will be nil if the Channel was not previously cached in the state cache.
+	BeforeUpdate *Channel `json:"-"`
 }
 
 // ChannelDelete is the data for a ChannelDelete event.
 type ChannelDelete struct {
 	*Channel
+	// BeforeDelete will be nil if the Channel was not previously cached in the state cache.
+	BeforeDelete *Channel `json:"-"`
 }
 
 // ChannelPinsUpdate stores data for a ChannelPinsUpdate event.
@@ -82,11 +119,17 @@ type GuildCreate struct {
 // GuildUpdate is the data for a GuildUpdate event.
 type GuildUpdate struct {
 	*Guild
+	// BeforeUpdate will be nil if the Guild was not previously cached in the state cache.
+	// Its Members, Presences, Channels and VoiceStates are always nil.
+	BeforeUpdate *Guild `json:"-"`
 }
 
 // GuildDelete is the data for a GuildDelete event.
 type GuildDelete struct {
 	*Guild
+	// BeforeDelete will be nil if the Guild was not previously cached in the state cache.
+	// Its Members, Presences, Channels and VoiceStates are always nil.
+	BeforeDelete *Guild `json:"-"`
 }
 
 // GuildBanAdd is the data for a GuildBanAdd event.
@@ -109,11 +152,15 @@ type GuildMemberAdd struct {
 // GuildMemberUpdate is the data for a GuildMemberUpdate event.
 type GuildMemberUpdate struct {
 	*Member
+	// BeforeUpdate will be nil if the Member was not previously cached in the state cache.
+	BeforeUpdate *Member `json:"-"`
 }
 
 // GuildMemberRemove is the data for a GuildMemberRemove event.
 type GuildMemberRemove struct {
 	*Member
+	// BeforeDelete will be nil if the Member was not previously cached in the state cache.
+	BeforeDelete *Member `json:"-"`
 }
 
 // GuildRoleCreate is the data for a GuildRoleCreate event.
@@ -124,18 +171,25 @@ type GuildRoleCreate struct {
 // GuildRoleUpdate is the data for a GuildRoleUpdate event.
 type GuildRoleUpdate struct {
 	*GuildRole
+	// BeforeUpdate will be nil if the Role was not previously cached in the state cache.
+	BeforeUpdate *Role `json:"-"`
 }
 
 // A GuildRoleDelete is the data for a GuildRoleDelete event.
 type GuildRoleDelete struct {
 	GuildID string `json:"guild_id"`
 	RoleID  string `json:"role_id"`
+	// BeforeDelete will be nil if the Role was not previously cached in the state cache.
+	BeforeDelete *Role `json:"-"`
 }
 
 // A GuildEmojisUpdate is the data for a guild emoji update event.
 type GuildEmojisUpdate struct {
 	GuildID string   `json:"guild_id"`
 	Emojis  []*Emoji `json:"emojis"`
+
+	// BeforeUpdate will be nil if the Guild was not previously cached in the state cache.
+	BeforeUpdate []*Emoji `json:"-"`
 }
 
 // A GuildMembersChunk is the data for a GuildMembersChunk event.
@@ -175,6 +229,8 @@ type MessageUpdate struct {
 // MessageDelete is the data for a MessageDelete event.
 type MessageDelete struct {
 	*Message
+	// BeforeDelete will be nil if the Message was not previously cached in the state cache.
+	BeforeDelete *Message `json:"-"`
 }
 
 // MessageReactionAdd is the data for a MessageReactionAdd event.
@@ -203,6 +259,8 @@ type PresencesReplace []*Presence
 // PresenceUpdate is the data for a PresenceUpdate event.
 type PresenceUpdate struct {
 	*Presence
+	// BeforeUpdate will be nil if the Presence was not previously cached in the state cache.
+	BeforeUpdate *Presence `json:"-"`
 }
 
 // Resumed is the data for a Resumed event.
@@ -258,6 +316,8 @@ type VoiceServerUpdate struct {
 // VoiceStateUpdate is the data for a VoiceStateUpdate event.
 type VoiceStateUpdate struct {
 	*VoiceState
+	// BeforeUpdate will be nil if the VoiceState was not previously cached in the state cache.
+	BeforeUpdate *VoiceState `json:"-"`
 }
 
 // MessageDeleteBulk is the data for a MessageDeleteBulk event
@@ -265,6 +325,8 @@ type MessageDeleteBulk struct {
 	Messages  []string `json:"ids"`
 	ChannelID string   `json:"channel_id"`
 	GuildID   string   `json:"guild_id"`
+	// BeforeDelete holds the deleted Messages which were cached in the state cache.
+	BeforeDelete []*Message `json:"-"`
 }
 
 // WebhooksUpdate is the data for a WebhooksUpdate event
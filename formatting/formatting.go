@@ -0,0 +1,120 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package formatting helps to build message content: it escapes text,
+// builds mentions and timestamps and splits long text into messages.
+//
+//	content := "Hello " + formatting.User(userID) + ", your report " +
+//		formatting.Escape(title) + " was closed " +
+//		formatting.Timestamp(time.Now(), formatting.TimestampRelative)
+package formatting
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mentions of everyone in a channel.
+const (
+	Everyone = "@everyone"
+	Here     = "@here"
+)
+
+// User returns a mention of a user.
+func User(userID string) string {
+	return "<@" + userID + ">"
+}
+
+// Role returns a mention of a role.
+func Role(roleID string) string {
+	return "<@&" + roleID + ">"
+}
+
+// Channel returns a mention of a channel.
+func Channel(channelID string) string {
+	return "<#" + channelID + ">"
+}
+
+// Command returns a mention of a slash command, which can be clicked to use it.
+// name : The name of the command, including the names of subcommands separated by spaces.
+func Command(name, commandID string) string {
+	return "</" + name + ":" + commandID + ">"
+}
+
+// Emoji returns a custom emoji.
+func Emoji(name, emojiID string, animated bool) string {
+	if animated {
+		return "<a:" + name + ":" + emojiID + ">"
+	}
+	return "<:" + name + ":" + emojiID + ">"
+}
+
+// TimestampStyle is the style in which Discord shows a timestamp.
+type TimestampStyle string
+
+// Block contains the known TimestampStyle values
+const (
+	TimestampShortTime     TimestampStyle = "t" // 4:20 PM
+	TimestampLongTime      TimestampStyle = "T" // 4:20:30 PM
+	TimestampShortDate     TimestampStyle = "d" // 06/20/2021
+	TimestampLongDate      TimestampStyle = "D" // June 20, 2021
+	TimestampShortDateTime TimestampStyle = "f" // June 20, 2021 4:20 PM, the default
+	TimestampLongDateTime  TimestampStyle = "F" // Sunday, June 20, 2021 4:20 PM
+	TimestampRelative      TimestampStyle = "R" // 2 months ago
+)
+
+// Timestamp returns a timestamp, which Discord shows in the time zone and
+// language of the reader.
+// style : The style of the timestamp, empty for the default style.
+func Timestamp(t time.Time, style TimestampStyle) string {
+	ts := "<t:" + strconv.FormatInt(t.Unix(), 10)
+	if style != "" {
+		ts += ":" + string(style)
+	}
+	return ts + ">"
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, `*`, `\*`, `_`, `\_`, `~`, `\~`, "`", "\\`", `|`, `\|`,
+	`>`, `\>`, `<`, `\<`, `#`, `\#`, `[`, `\[`, `]`, `\]`,
+)
+
+// EscapeMarkdown escapes all markdown in text, so it is shown as it is.
+// As angle brackets are escaped, user, role and channel mentions and
+// custom emojis are shown as text as well.
+func EscapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// zeroWidthSpace is invisible and breaks mentions.
+const zeroWidthSpace = "\u200b"
+
+var mentionEscaper = strings.NewReplacer(
+	Everyone, "@"+zeroWidthSpace+"everyone",
+	Here, "@"+zeroWidthSpace+"here",
+	"<@", "<@"+zeroWidthSpace,
+)
+
+// EscapeMentions breaks @everyone, @here, user and role mentions in text,
+// so they don't notify anyone. Markdown is left as it is.
+// To control who is notified by a message, AllowedMentions of a
+// MessageSend can be used as well.
+func EscapeMentions(text string) string {
+	return mentionEscaper.Replace(text)
+}
+
+// Escape escapes markdown and mentions, so text is shown as it is and
+// doesn't notify anyone.
+func Escape(text string) string {
+	return EscapeMentions(EscapeMarkdown(text))
+}
+
+// EscapeCodeBlock breaks code block fences in text, so it can be put into a code block.
+func EscapeCodeBlock(text string) string {
+	return strings.Replace(text, "```", "`"+zeroWidthSpace+"``", -1)
+}
@@ -0,0 +1,118 @@
+package formatting
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestMentions(t *testing.T) {
+	tests := map[string]string{
+		User("1"):                 "<@1>",
+		Role("2"):                 "<@&2>",
+		Channel("3"):              "<#3>",
+		Command("ban user", "4"):  "</ban user:4>",
+		Emoji("wave", "5", false): "<:wave:5>",
+		Emoji("dance", "6", true): "<a:dance:6>",
+	}
+	for got, want := range tests {
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	ts := time.Unix(1622548800, 0)
+	if got := Timestamp(ts, ""); got != "<t:1622548800>" {
+		t.Errorf("unexpected default timestamp %q", got)
+	}
+	if got := Timestamp(ts, TimestampRelative); got != "<t:1622548800:R>" {
+		t.Errorf("unexpected relative timestamp %q", got)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		fn   func(string) string
+		in   string
+		want string
+	}{
+		{EscapeMarkdown, "**bold** _it_ `code` <@1>", `\*\*bold\*\* \_it\_ \` + "`code\\`" + ` \<@1\>`},
+		{EscapeMentions, "@everyone <@1> <@&2> **hi**", "@\u200beveryone <@\u200b1> <@\u200b&2> **hi**"},
+		{Escape, "@here > quote", "@\u200bhere \\> quote"},
+		{EscapeCodeBlock, "```go", "`\u200b``go"},
+	}
+	for _, test := range tests {
+		if got := test.fn(test.in); got != test.want {
+			t.Errorf("escaping %q: got %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	if chunks := Split("short", 0); len(chunks) != 1 || chunks[0] != "short" {
+		t.Errorf("expected a single chunk, got %q", chunks)
+	}
+
+	lines := make([]string, 100)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", 39)
+	}
+	chunks := Split(strings.Join(lines, "\n"), 200)
+	for _, c := range chunks {
+		if utf8.RuneCountInString(c) > 200 || strings.HasPrefix(c, "\n") || strings.HasSuffix(c, "\n") {
+			t.Errorf("chunk isn't split at a line break: %q", c)
+		}
+	}
+	if got := strings.Join(chunks, "\n"); got != strings.Join(lines, "\n") {
+		t.Errorf("chunks don't add up to the text")
+	}
+
+	if chunks := Split(strings.Repeat("ä", 50), 20); len(chunks) != 3 || utf8.RuneCountInString(chunks[0]) != 16 {
+		t.Errorf("expected hard splits at runes, got %q", chunks)
+	}
+}
+
+func TestSplitCodeBlocks(t *testing.T) {
+	// The code block fits into the second chunk, so it isn't split.
+	text := strings.Repeat("a", 50) + "\n```go\n" + strings.Repeat("b\n", 20) + "```"
+	chunks := Split(text, 80)
+	if len(chunks) != 2 || chunks[0] != strings.Repeat("a", 50) || !strings.HasPrefix(chunks[1], "```go\n") {
+		t.Errorf("expected the code block in its own chunk, got %q", chunks)
+	}
+
+	// A long code block is closed and opened again.
+	text = "```go\n" + strings.Repeat("fmt.Println()\n", 20) + "```"
+	chunks = Split(text, 100)
+	if len(chunks) < 3 {
+		t.Fatalf("expected at least 3 chunks, got %q", chunks)
+	}
+	for i, c := range chunks {
+		if utf8.RuneCountInString(c) > 100 {
+			t.Errorf("chunk %d is too long: %d", i, utf8.RuneCountInString(c))
+		}
+		if !strings.HasPrefix(c, "```go\n") || !strings.HasSuffix(c, "```") {
+			t.Errorf("chunk %d isn't a complete code block: %q", i, c)
+		}
+		if strings.Count(c, "```")%2 != 0 {
+			t.Errorf("chunk %d has unbalanced fences: %q", i, c)
+		}
+	}
+	// A fence at the end of a chunk isn't cut.
+	chunks = Split(strings.Repeat("x", 1994)+"```go\ncode\n```", 0)
+	if len(chunks) != 2 || chunks[0] != strings.Repeat("x", 1994) || chunks[1] != "```go\ncode\n```" {
+		t.Errorf("expected the fence in the second chunk, got %q", chunks)
+	}
+
+	// Spaces in code blocks are kept.
+	chunks = Split("```\n"+strings.Repeat("ab ", 30)+"\n```", 40)
+	var code string
+	for _, c := range chunks {
+		code += strings.TrimSuffix(strings.TrimPrefix(c, "```\n"), "\n```")
+	}
+	if code != strings.Repeat("ab ", 30) {
+		t.Errorf("expected the code to be kept, got %q from %q", code, chunks)
+	}
+}
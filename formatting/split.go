@@ -0,0 +1,154 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the splitting of long text into messages.
+
+package formatting
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// MessageLimit is the maximum number of characters of message content.
+const MessageLimit = 2000
+
+// minSplitLimit is the smallest limit which leaves room to close and re-open code blocks.
+const minSplitLimit = 16
+
+const fenceClose = "\n```"
+
+var patternFenceLanguage = regexp.MustCompile(`^[a-zA-Z0-9_+\-.#]+$`)
+
+// Split splits text into chunks of at most limit characters, which can be
+// sent as separate messages. Text is split at line breaks if possible,
+// otherwise at spaces. Code blocks are only split if they don't fit into
+// a chunk; then the block is closed at the end of the chunk and opened
+// again, with its language, at the start of the next one.
+// text  : The text to split.
+// limit : The maximum length of a chunk, 0 for MessageLimit.
+func Split(text string, limit int) (chunks []string) {
+	if limit <= 0 {
+		limit = MessageLimit
+	}
+	if limit < minSplitLimit {
+		limit = minSplitLimit
+	}
+
+	// reopen is the fence which continues a code block of the previous chunk.
+	reopen := ""
+	for text != "" {
+		avail := limit - utf8.RuneCountInString(reopen)
+		if utf8.RuneCountInString(text) <= avail {
+			chunks = append(chunks, reopen+text)
+			break
+		}
+
+		// Leave room to close a code block, and don't cut into a fence.
+		end := runeOffset(text, avail-len(fenceClose))
+		for k := 1; k <= 2 && k <= end; k++ {
+			if strings.HasPrefix(text[end-k:], "```") {
+				end -= k
+				break
+			}
+		}
+		cut, next, open, lang := splitPoint(reopen, text[:end])
+
+		chunk := reopen + text[:cut]
+		reopen = ""
+		if open {
+			chunk += fenceClose
+			reopen = "```" + lang + "\n"
+			if utf8.RuneCountInString(reopen) > limit/4 {
+				reopen = "```\n"
+			}
+		}
+
+		chunks = append(chunks, chunk)
+		text = text[next:]
+	}
+	return
+}
+
+// splitPoint returns where to split s, the start of the next chunk and
+// whether the split is inside of a code block, with its language.
+// prefix is the fence s continues, if any.
+func splitPoint(prefix, s string) (cut, next int, open bool, lang string) {
+	type point struct {
+		cut, next int
+		open      bool
+		lang      string
+	}
+	var lastOutside, lastInside, lastSpace point
+
+	open, lang = prefix != "", fenceLanguage(strings.TrimPrefix(prefix, "```"))
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "```"):
+			if open {
+				open = false
+			} else {
+				open, lang = true, fenceLanguage(s[i+3:])
+				// The line break after the opening fence isn't a split point.
+				if j := strings.IndexByte(s[i+3:], '\n'); j >= 0 {
+					i += 3 + j
+					continue
+				}
+			}
+			i += 2
+		case s[i] == '\n' && i > 0:
+			if open {
+				lastInside = point{i, i + 1, open, lang}
+			} else {
+				lastOutside = point{i, i + 1, open, lang}
+			}
+		case s[i] == ' ' && i > 0:
+			// Spaces are part of the code in code blocks, so they are kept.
+			if open {
+				lastSpace = point{i, i, open, lang}
+			} else {
+				lastSpace = point{i, i + 1, open, lang}
+			}
+		}
+	}
+
+	// Prefer line breaks outside of code blocks, then line breaks in them, then spaces.
+	for _, p := range []point{lastOutside, lastInside, lastSpace} {
+		if p.cut > 0 {
+			return p.cut, p.next, p.open, p.lang
+		}
+	}
+	return len(s), len(s), open, lang
+}
+
+// fenceLanguage returns the language of a code block, s is the text after its opening fence.
+func fenceLanguage(s string) string {
+	line := s
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		line = s[:i]
+	} else {
+		// The language is only known when the line is complete.
+		return ""
+	}
+
+	if patternFenceLanguage.MatchString(line) {
+		return line
+	}
+	return ""
+}
+
+// runeOffset returns the byte offset of the n-th rune of s.
+func runeOffset(s string, n int) int {
+	for i := range s {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(s)
+}
@@ -0,0 +1,162 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the hooks which can be used to trace REST requests.
+
+package discordgo
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// A RequestHook is notified about every REST request sent by a Session.
+// Hooks are called synchronously from the goroutine sending the request,
+// so they should return quickly.
+type RequestHook interface {
+	// BeforeRequest is called before every attempt of a request is sent.
+	BeforeRequest(*RequestInfo)
+
+	// AfterResponse is called after a response was received, or the request failed.
+	AfterResponse(*ResponseInfo)
+
+	// RateLimitWait is called after a request had to wait for a rate limit.
+	RateLimitWait(*RateLimitWaitInfo)
+}
+
+// RequestInfo describes a REST request.
+type RequestInfo struct {
+	Method string
+	URL    string
+
+	// Route is the URL path with all IDs, tokens and other variable
+	// parts replaced by placeholders, e.g. "/channels/:id/messages".
+	Route string
+
+	// Bucket is the key of the rate limit bucket of the request.
+	Bucket string
+
+	// Attempt is 0 for the first attempt and increases with every retry.
+	Attempt int
+}
+
+// ResponseInfo describes the outcome of a REST request.
+type ResponseInfo struct {
+	RequestInfo
+
+	// StatusCode is 0 if no response was received.
+	StatusCode int
+	Latency    time.Duration
+
+	// Err is the transport error, if any.
+	Err error
+}
+
+// RateLimitWaitInfo describes a wait caused by a rate limit.
+type RateLimitWaitInfo struct {
+	RequestInfo
+
+	Wait time.Duration
+}
+
+var apiPathPrefix = regexp.MustCompile(`^/api(/v[0-9]+)?`)
+
+// RouteTemplate returns the route of a REST URL with all IDs, tokens,
+// emojis and invite codes replaced by placeholders, so it can be used to
+// group requests without leaking secrets or creating unbounded label sets.
+// e.g. "https://discord.com/api/v8/channels/1234/messages?limit=5" -> "/channels/:id/messages"
+func RouteTemplate(urlStr string) string {
+	path := strings.SplitN(urlStr, "?", 2)[0]
+	if u, err := url.Parse(urlStr); err == nil {
+		path = u.EscapedPath()
+	}
+	path = apiPathPrefix.ReplaceAllString(path, "")
+
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if part == "" || i == 0 {
+			continue
+		}
+
+		prev := parts[i-1]
+		prevPrev := ""
+		if i > 1 {
+			prevPrev = parts[i-2]
+		}
+
+		switch {
+		case isNumeric(part):
+			parts[i] = ":id"
+		case prev == "reactions":
+			parts[i] = ":emoji"
+		case prev == "invites", prev == "templates":
+			parts[i] = ":code"
+		case prev == ":id" && (prevPrev == "webhooks" || prevPrev == "interactions"):
+			parts[i] = ":token"
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestInfo returns the RequestInfo passed to the session's request hooks.
+func newRequestInfo(method, urlStr string, bucket *Bucket, attempt int) RequestInfo {
+	return RequestInfo{
+		Method:  method,
+		URL:     urlStr,
+		Route:   RouteTemplate(urlStr),
+		Bucket:  bucket.Key,
+		Attempt: attempt,
+	}
+}
+
+func (s *Session) hookBeforeRequest(info *RequestInfo) {
+	for _, h := range s.RequestHooks {
+		h.BeforeRequest(info)
+	}
+}
+
+func (s *Session) hookAfterResponse(info *ResponseInfo) {
+	for _, h := range s.RequestHooks {
+		h.AfterResponse(info)
+	}
+}
+
+func (s *Session) hookRateLimitWait(info *RateLimitWaitInfo) {
+	if info.Wait <= 0 {
+		return
+	}
+	for _, h := range s.RequestHooks {
+		h.RateLimitWait(info)
+	}
+}
+
+// lockBucket locks the bucket and reports the time spent waiting for it to the request hooks.
+func (s *Session) lockBucket(method, urlStr string, bucket *Bucket, attempt int, priority RequestPriority) *Bucket {
+	b, wait := s.Ratelimiter.lockBucketObject(bucket, priority)
+	if len(s.RequestHooks) > 0 {
+		s.hookRateLimitWait(&RateLimitWaitInfo{
+			RequestInfo: newRequestInfo(method, urlStr, b, attempt),
+			Wait:        wait,
+		})
+	}
+	return b
+}
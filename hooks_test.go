@@ -0,0 +1,92 @@
+package discordgo
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	tests := map[string]string{
+		EndpointChannelMessages("81384788765712384") + "?limit=5":                                "/channels/:id/messages",
+		EndpointMessageReaction("81384788765712384", "81384788765712385", "%F0%9F%91%8D", "@me"): "/channels/:id/messages/:id/reactions/:emoji/@me",
+		EndpointWebhookToken("81384788765712384", "secret-token"):                                "/webhooks/:id/:token",
+		EndpointInteractionResponse("81384788765712384", "secret-token"):                         "/interactions/:id/:token/callback",
+		EndpointInvite("abcdef"):                             "/invites/:code",
+		"https://discord.com/api/v8/guilds/templates/abcdef": "/guilds/templates/:code",
+	}
+
+	for urlStr, expected := range tests {
+		if route := RouteTemplate(urlStr); route != expected {
+			t.Errorf("RouteTemplate(%q) = %q, expected %q", urlStr, route, expected)
+		}
+	}
+}
+
+func TestMetricsCollector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	metrics := NewMetricsCollector()
+	s := newRetryTestSession(NewRetryPolicy())
+	s.RequestHooks = []RequestHook{metrics}
+
+	urlStr := srv.URL + "/api/v8/channels/81384788765712384/messages"
+	for i := 0; i < 2; i++ {
+		if _, err := s.RequestWithBucketID("GET", urlStr, nil, urlStr); err != nil {
+			t.Fatalf("request returned error: %v", err)
+		}
+	}
+
+	if n := metrics.Requests("GET", "/channels/:id/messages"); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+
+	var buf bytes.Buffer
+	if err := metrics.WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `discordgo_requests_total{method="GET",route="/channels/:id/messages",status="200"} 2`) {
+		t.Errorf("unexpected Prometheus output:\n%s", buf.String())
+	}
+}
+
+// countingHook counts the calls of a RequestHook.
+type countingHook struct {
+	before, after int
+	err           error
+}
+
+func (h *countingHook) BeforeRequest(*RequestInfo) { h.before++ }
+
+func (h *countingHook) AfterResponse(info *ResponseInfo) {
+	h.after++
+	h.err = info.Err
+}
+
+func (h *countingHook) RateLimitWait(*RateLimitWaitInfo) {}
+
+func TestRequestHooksInvalidRateLimitHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "invalid")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	hook := &countingHook{}
+	s := newRetryTestSession(NewRetryPolicy())
+	s.RequestHooks = []RequestHook{hook}
+
+	urlStr := srv.URL + "/api/v8/channels/81384788765712384/messages"
+	if _, err := s.RequestWithBucketID("GET", urlStr, nil, urlStr); err == nil {
+		t.Fatal("expected an error for the invalid rate limit headers")
+	}
+
+	if hook.before != 1 || hook.after != 1 || hook.err == nil {
+		t.Errorf("expected one request and a response with the error, got %d, %d, %v", hook.before, hook.after, hook.err)
+	}
+}
@@ -0,0 +1,198 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the tracking of invalid REST requests.
+
+package discordgo
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrInvalidRequestLimit is returned by REST functions when too many invalid
+// requests were sent recently and sending more could get the IP address banned.
+var ErrInvalidRequestLimit = errors.New("too many invalid requests (401, 403, 429) were sent recently, request was not sent")
+
+// Discord temporarily bans IP addresses which send InvalidRequestBanLimit
+// invalid requests within InvalidRequestBanWindow.
+const (
+	InvalidRequestBanLimit  = 10000
+	InvalidRequestBanWindow = 10 * time.Minute
+)
+
+// InvalidRequestLimiter counts responses which Discord considers invalid
+// (HTTP 401, 403 and 429) in a sliding window, and slows down or rejects
+// further requests before Discord's ban threshold is reached.
+// The same limiter can be shared by all sessions sending requests from one IP address.
+type InvalidRequestLimiter struct {
+	sync.Mutex
+
+	// The length of the sliding window.
+	Window time.Duration
+
+	// Once SlowdownThreshold invalid requests were counted within Window,
+	// every request is delayed by SlowdownDelay. 0 disables slowing down.
+	SlowdownThreshold int
+	SlowdownDelay     time.Duration
+
+	// Once RejectThreshold invalid requests were counted within Window,
+	// requests fail with ErrInvalidRequestLimit until enough of them have
+	// left the window. 0 disables rejecting requests.
+	RejectThreshold int
+
+	hits []time.Time
+	open bool
+}
+
+// NewInvalidRequestLimiter returns an InvalidRequestLimiter which starts
+// slowing down requests at half of Discord's limit and rejects them at 90% of it.
+func NewInvalidRequestLimiter() *InvalidRequestLimiter {
+	return &InvalidRequestLimiter{
+		Window:            InvalidRequestBanWindow,
+		SlowdownThreshold: InvalidRequestBanLimit / 2,
+		SlowdownDelay:     time.Second,
+		RejectThreshold:   InvalidRequestBanLimit * 9 / 10,
+	}
+}
+
+// Count returns the number of invalid requests in the current window.
+func (l *InvalidRequestLimiter) Count() int {
+	l.Lock()
+	defer l.Unlock()
+
+	l.prune(time.Now())
+	return len(l.hits)
+}
+
+// Open reports whether the limiter currently rejects requests.
+func (l *InvalidRequestLimiter) Open() bool {
+	l.Lock()
+	defer l.Unlock()
+
+	l.prune(time.Now())
+	return l.open
+}
+
+// prune drops all hits which left the window and closes the circuit
+// if the count dropped below RejectThreshold.
+// The caller must hold the lock.
+func (l *InvalidRequestLimiter) prune(now time.Time) {
+	cutoff := now.Add(-l.Window)
+
+	i := 0
+	for i < len(l.hits) && !l.hits[i].After(cutoff) {
+		i++
+	}
+	if i > 0 {
+		l.hits = append(l.hits[:0], l.hits[i:]...)
+	}
+
+	if l.open && len(l.hits) < l.RejectThreshold {
+		l.open = false
+	}
+}
+
+// retryAfter returns how long it takes until the count drops below RejectThreshold.
+// The caller must hold the lock.
+func (l *InvalidRequestLimiter) retryAfter(now time.Time) time.Duration {
+	excess := len(l.hits) - l.RejectThreshold
+	if excess < 0 || excess >= len(l.hits) {
+		return 0
+	}
+
+	return l.hits[excess].Add(l.Window).Sub(now)
+}
+
+// record counts an invalid request and reports whether the circuit opened because of it.
+func (l *InvalidRequestLimiter) record() (opened bool, count int, retryAfter time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	now := time.Now()
+	l.prune(now)
+	l.hits = append(l.hits, now)
+
+	if !l.open && l.RejectThreshold > 0 && len(l.hits) >= l.RejectThreshold {
+		l.open = true
+		return true, len(l.hits), l.retryAfter(now)
+	}
+
+	return false, len(l.hits), 0
+}
+
+// check returns how long a request should be delayed, or
+// ErrInvalidRequestLimit if it should not be sent at all.
+func (l *InvalidRequestLimiter) check() (time.Duration, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.prune(time.Now())
+
+	if l.open {
+		return 0, ErrInvalidRequestLimit
+	}
+
+	if l.SlowdownThreshold > 0 && len(l.hits) >= l.SlowdownThreshold {
+		return l.SlowdownDelay, nil
+	}
+
+	return 0, nil
+}
+
+// isInvalidRequest reports whether Discord counts the response towards the invalid request limit.
+// 429 responses with a shared scope are excluded, as documented by Discord.
+func isInvalidRequest(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	case http.StatusTooManyRequests:
+		return resp.Header.Get("X-RateLimit-Scope") != "shared"
+	}
+
+	return false
+}
+
+// checkInvalidRequests waits or fails depending on the session's InvalidRequestLimiter.
+func (s *Session) checkInvalidRequests() error {
+	if s.InvalidRequests == nil {
+		return nil
+	}
+
+	delay, err := s.InvalidRequests.check()
+	if err != nil {
+		return err
+	}
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	return nil
+}
+
+// recordInvalidRequest counts resp if it is an invalid request and fires
+// an InvalidRequestLimit event when the limiter starts rejecting requests.
+func (s *Session) recordInvalidRequest(urlStr string, resp *http.Response) {
+	if s.InvalidRequests == nil || !isInvalidRequest(resp) {
+		return
+	}
+
+	opened, count, retryAfter := s.InvalidRequests.record()
+	if !opened {
+		return
+	}
+
+	s.log(LogWarning, "%d invalid requests within %s, rejecting requests for %s", count, s.InvalidRequests.Window, retryAfter)
+	s.handleEvent(invalidRequestLimitEventType, &InvalidRequestLimit{
+		Count:      count,
+		Window:     s.InvalidRequests.Window,
+		RetryAfter: retryAfter,
+		URL:        urlStr,
+	})
+}
@@ -0,0 +1,72 @@
+package discordgo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInvalidRequestLimit(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	s := newRetryTestSession(NewRetryPolicy())
+	s.SyncEvents = true
+	s.InvalidRequests = &InvalidRequestLimiter{
+		Window:          time.Minute,
+		RejectThreshold: 3,
+	}
+
+	var events int32
+	s.AddHandler(func(_ *Session, e *InvalidRequestLimit) {
+		atomic.AddInt32(&events, 1)
+		if e.Count != 3 {
+			t.Errorf("expected count 3, got %d", e.Count)
+		}
+	})
+
+	urlStr := srv.URL + "/api/v8/channels/forbidden"
+	for i := 0; i < 3; i++ {
+		if _, err := s.RequestWithBucketID("GET", urlStr, nil, urlStr); err == ErrInvalidRequestLimit {
+			t.Fatalf("request %d was rejected", i)
+		}
+	}
+
+	if _, err := s.RequestWithBucketID("GET", urlStr, nil, urlStr); err != ErrInvalidRequestLimit {
+		t.Errorf("expected ErrInvalidRequestLimit, got %v", err)
+	}
+
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("expected 3 requests to be sent, got %d", calls)
+	}
+	if atomic.LoadInt32(&events) != 1 {
+		t.Errorf("expected 1 InvalidRequestLimit event, got %d", events)
+	}
+}
+
+func TestInvalidRequestLimiterWindow(t *testing.T) {
+	l := &InvalidRequestLimiter{
+		Window:          50 * time.Millisecond,
+		RejectThreshold: 2,
+	}
+
+	l.record()
+	if opened, _, _ := l.record(); !opened {
+		t.Fatal("expected limiter to open")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if l.Open() {
+		t.Error("expected limiter to close after the window passed")
+	}
+	if l.Count() != 0 {
+		t.Errorf("expected count 0, got %d", l.Count())
+	}
+}
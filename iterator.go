@@ -0,0 +1,465 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains iterators which page through list endpoints of the REST API.
+
+package discordgo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// Iterator errors
+var (
+	// ErrIteratorDirection is returned by iterators of endpoints which can't page in the requested direction.
+	ErrIteratorDirection = errors.New("the endpoint can't be iterated in this direction")
+	// ErrIteratorStart is returned by iterators of endpoints which can only
+	// page backward from a cursor, when neither Start nor Until is set.
+	ErrIteratorStart = errors.New("the endpoint can only be iterated backward from Start or Until")
+)
+
+// IteratorDirection is the direction in which an iterator pages through a list.
+type IteratorDirection int
+
+// Iterator directions
+const (
+	// IterateBackward goes from newer to older items, i.e. from higher to lower IDs.
+	IterateBackward IteratorDirection = iota
+	// IterateForward goes from older to newer items, i.e. from lower to higher IDs.
+	IterateForward
+)
+
+// IteratorOptions configure an iterator.
+type IteratorOptions struct {
+	Direction IteratorDirection
+
+	// Start is the ID to start after (forward) or before (backward), it is not included.
+	// If empty, backward iterators start at the newest item and forward iterators at the oldest.
+	Start string
+
+	// Limit is the maximum number of items to return, 0 for no limit.
+	Limit int
+
+	// PageSize is the number of items to request at once, 0 for the largest page size of the endpoint.
+	PageSize int
+
+	// Since and Until stop the iteration at items whose ID was created
+	// outside of this time range. They are ignored if zero.
+	// For members and users this is the time the account was created.
+	Since time.Time
+	Until time.Time
+}
+
+// pageFunc requests a page of items before or after the cursor.
+type pageFunc func(cursor string, forward bool, limit int) ([]interface{}, error)
+
+// iterator pages through a list endpoint. The typed iterators embed it and
+// add accessors for the current item.
+type iterator struct {
+	fetch    pageFunc
+	id       func(interface{}) string
+	opts     IteratorOptions
+	ctx      context.Context
+	maxPage  int
+	backward bool // whether the endpoint supports paging backward
+	forward  bool // whether the endpoint supports paging forward
+	// needsCursor is set for endpoints which return their oldest page when
+	// paging backward without a cursor.
+	needsCursor bool
+
+	cursor   string
+	page     []interface{}
+	lastPage map[string]bool
+	current  interface{}
+	count    int
+	started  bool
+	done     bool
+	err      error
+}
+
+func newIterator(fetch pageFunc, id func(interface{}) string, maxPage int, backward, forward bool, opts IteratorOptions, options []RequestOption) *iterator {
+	it := &iterator{
+		fetch:    fetch,
+		id:       id,
+		opts:     opts,
+		ctx:      newRequestConfig("", options).Context,
+		maxPage:  maxPage,
+		backward: backward,
+		forward:  forward,
+	}
+
+	if it.opts.PageSize <= 0 || it.opts.PageSize > maxPage {
+		it.opts.PageSize = maxPage
+	}
+	return it
+}
+
+// Next advances the iterator to the next item, it requests the next page
+// when needed. It returns false when there are no more items or an error
+// occurred, which is returned by Err.
+func (it *iterator) Next() bool {
+	if !it.started {
+		it.start()
+	}
+
+	for {
+		if it.done && len(it.page) == 0 {
+			return false
+		}
+		if it.opts.Limit > 0 && it.count >= it.opts.Limit {
+			it.done, it.page = true, nil
+			return false
+		}
+
+		if len(it.page) == 0 {
+			it.nextPage()
+			continue
+		}
+
+		item := it.page[0]
+		it.page = it.page[1:]
+
+		skip, stop := it.outOfBounds(it.id(item))
+		if stop {
+			it.done, it.page = true, nil
+			return false
+		}
+		if skip {
+			continue
+		}
+
+		it.current = item
+		it.count++
+		return true
+	}
+}
+
+// Err returns the error which stopped the iterator, if any.
+func (it *iterator) Err() error {
+	return it.err
+}
+
+// start sets the initial cursor.
+func (it *iterator) start() {
+	it.started = true
+
+	if it.opts.Direction == IterateForward && !it.forward || it.opts.Direction == IterateBackward && !it.backward {
+		it.err, it.done = ErrIteratorDirection, true
+		return
+	}
+
+	if it.opts.Direction == IterateBackward && it.needsCursor && it.opts.Start == "" && it.opts.Until.IsZero() {
+		it.err, it.done = ErrIteratorStart, true
+		return
+	}
+
+	it.cursor = it.opts.Start
+	if it.cursor != "" {
+		return
+	}
+
+	if it.opts.Direction == IterateForward {
+		it.cursor = "0"
+		if !it.opts.Since.IsZero() {
+			it.cursor = SnowflakeFromTime(it.opts.Since.Add(-time.Millisecond)).String()
+		}
+	} else if !it.opts.Until.IsZero() {
+		it.cursor = SnowflakeFromTime(it.opts.Until.Add(time.Millisecond)).String()
+	}
+}
+
+// nextPage requests the next page and moves the cursor.
+func (it *iterator) nextPage() {
+	if it.ctx != nil {
+		if err := it.ctx.Err(); err != nil {
+			it.err, it.done = err, true
+			return
+		}
+	}
+
+	limit := it.opts.PageSize
+	if it.opts.Limit > 0 && it.opts.Limit-it.count < limit {
+		limit = it.opts.Limit - it.count
+	}
+
+	forward := it.opts.Direction == IterateForward
+	items, err := it.fetch(it.cursor, forward, limit)
+	if err != nil {
+		it.err, it.done = err, true
+		return
+	}
+	if len(items) < limit {
+		it.done = true
+	}
+
+	// Discord doesn't return all endpoints in the same order.
+	sort.SliceStable(items, func(i, j int) bool {
+		if forward {
+			return SnowflakeLess(it.id(items[i]), it.id(items[j]))
+		}
+		return SnowflakeLess(it.id(items[j]), it.id(items[i]))
+	})
+
+	// Guard against endpoints which ignore the cursor and return the same page again.
+	page := make(map[string]bool, len(items))
+	fresh := items[:0]
+	for _, item := range items {
+		id := it.id(item)
+		if it.lastPage[id] || page[id] {
+			continue
+		}
+		page[id] = true
+		fresh = append(fresh, item)
+	}
+	if len(fresh) == 0 {
+		it.done = true
+		return
+	}
+
+	it.lastPage = page
+	it.page = fresh
+	it.cursor = it.id(fresh[len(fresh)-1])
+}
+
+// outOfBounds reports whether an item is outside of the time range, and
+// whether the iteration has passed the end of the range.
+func (it *iterator) outOfBounds(id string) (skip, stop bool) {
+	if it.opts.Since.IsZero() && it.opts.Until.IsZero() {
+		return
+	}
+
+	snowflake, err := ParseSnowflake(id)
+	if err != nil {
+		return
+	}
+	t := snowflake.Time()
+
+	before := !it.opts.Since.IsZero() && t.Before(it.opts.Since)
+	after := !it.opts.Until.IsZero() && t.After(it.opts.Until)
+
+	if it.opts.Direction == IterateForward {
+		return before, after
+	}
+	return after, before
+}
+
+// MessageIterator iterates over the messages of a channel.
+type MessageIterator struct {
+	*iterator
+}
+
+// Message returns the current message.
+func (it *MessageIterator) Message() *Message {
+	return it.current.(*Message)
+}
+
+// ChannelMessagesIterator returns an iterator over the messages of a channel.
+// channelID : The ID of a Channel.
+// opts      : Where to start and stop, the direction and page size.
+func (s *Session) ChannelMessagesIterator(channelID string, opts IteratorOptions, options ...RequestOption) *MessageIterator {
+	fetch := func(cursor string, forward bool, limit int) ([]interface{}, error) {
+		var messages []*Message
+		var err error
+		if forward {
+			messages, err = s.ChannelMessages(channelID, limit, "", cursor, "", options...)
+		} else {
+			messages, err = s.ChannelMessages(channelID, limit, cursor, "", "", options...)
+		}
+
+		items := make([]interface{}, len(messages))
+		for i, m := range messages {
+			items[i] = m
+		}
+		return items, err
+	}
+
+	id := func(item interface{}) string { return item.(*Message).ID }
+	return &MessageIterator{newIterator(fetch, id, 100, true, true, opts, options)}
+}
+
+// MemberIterator iterates over the members of a guild.
+type MemberIterator struct {
+	*iterator
+}
+
+// Member returns the current member.
+func (it *MemberIterator) Member() *Member {
+	return it.current.(*Member)
+}
+
+// GuildMembersIterator returns an iterator over the members of a guild,
+// ordered by their user IDs. It can only iterate forward.
+// guildID : The ID of a Guild.
+// opts    : Where to start and stop and the page size.
+func (s *Session) GuildMembersIterator(guildID string, opts IteratorOptions, options ...RequestOption) *MemberIterator {
+	fetch := func(cursor string, forward bool, limit int) ([]interface{}, error) {
+		members, err := s.GuildMembers(guildID, cursor, limit, options...)
+
+		items := make([]interface{}, len(members))
+		for i, m := range members {
+			items[i] = m
+		}
+		return items, err
+	}
+
+	id := func(item interface{}) string { return item.(*Member).User.ID }
+	return &MemberIterator{newIterator(fetch, id, 1000, false, true, opts, options)}
+}
+
+// BanIterator iterates over the bans of a guild.
+type BanIterator struct {
+	*iterator
+}
+
+// Ban returns the current ban.
+func (it *BanIterator) Ban() *GuildBan {
+	return it.current.(*GuildBan)
+}
+
+// GuildBansIterator returns an iterator over the bans of a guild, ordered by the IDs of the banned users.
+// Iterating backward needs a Start or Until, otherwise it fails with ErrIteratorStart.
+// guildID : The ID of a Guild.
+// opts    : Where to start and stop, the direction and page size.
+func (s *Session) GuildBansIterator(guildID string, opts IteratorOptions, options ...RequestOption) *BanIterator {
+	fetch := func(cursor string, forward bool, limit int) ([]interface{}, error) {
+		var bans []*GuildBan
+		var err error
+		if forward {
+			bans, err = s.guildBans(guildID, limit, "", cursor, options...)
+		} else {
+			bans, err = s.guildBans(guildID, limit, cursor, "", options...)
+		}
+
+		items := make([]interface{}, len(bans))
+		for i, b := range bans {
+			items[i] = b
+		}
+		return items, err
+	}
+
+	id := func(item interface{}) string { return item.(*GuildBan).User.ID }
+	it := newIterator(fetch, id, 1000, true, true, opts, options)
+	it.needsCursor = true
+	return &BanIterator{it}
+}
+
+// UserIterator iterates over users.
+type UserIterator struct {
+	*iterator
+}
+
+// User returns the current user.
+func (it *UserIterator) User() *User {
+	return it.current.(*User)
+}
+
+// MessageReactionsIterator returns an iterator over the users who reacted
+// to a message with an emoji, ordered by their IDs. It can only iterate forward.
+// channelID : The channel ID.
+// messageID : The message ID.
+// emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
+// opts      : Where to start and stop and the page size.
+func (s *Session) MessageReactionsIterator(channelID, messageID, emojiID string, opts IteratorOptions, options ...RequestOption) *UserIterator {
+	fetch := func(cursor string, forward bool, limit int) ([]interface{}, error) {
+		users, err := s.messageReactions(channelID, messageID, emojiID, limit, cursor, options...)
+
+		items := make([]interface{}, len(users))
+		for i, u := range users {
+			items[i] = u
+		}
+		return items, err
+	}
+
+	id := func(item interface{}) string { return item.(*User).ID }
+	return &UserIterator{newIterator(fetch, id, 100, false, true, opts, options)}
+}
+
+// UserGuildIterator iterates over the guilds of the current user.
+type UserGuildIterator struct {
+	*iterator
+}
+
+// Guild returns the current guild.
+func (it *UserGuildIterator) Guild() *UserGuild {
+	return it.current.(*UserGuild)
+}
+
+// UserGuildsIterator returns an iterator over the guilds the current user is a member of.
+// Iterating backward needs a Start or Until, otherwise it fails with ErrIteratorStart.
+// opts : Where to start and stop, the direction and page size.
+func (s *Session) UserGuildsIterator(opts IteratorOptions, options ...RequestOption) *UserGuildIterator {
+	fetch := func(cursor string, forward bool, limit int) ([]interface{}, error) {
+		var guilds []*UserGuild
+		var err error
+		if forward {
+			guilds, err = s.UserGuilds(limit, "", cursor, options...)
+		} else {
+			guilds, err = s.UserGuilds(limit, cursor, "", options...)
+		}
+
+		items := make([]interface{}, len(guilds))
+		for i, g := range guilds {
+			items[i] = g
+		}
+		return items, err
+	}
+
+	id := func(item interface{}) string { return item.(*UserGuild).ID }
+	it := newIterator(fetch, id, 200, true, true, opts, options)
+	it.needsCursor = true
+	return &UserGuildIterator{it}
+}
+
+// AuditLogIterator iterates over the entries of a guild's audit log.
+type AuditLogIterator struct {
+	*iterator
+
+	log *GuildAuditLog
+}
+
+// Entry returns the current audit log entry.
+func (it *AuditLogIterator) Entry() *AuditLogEntry {
+	return it.current.(*AuditLogEntry)
+}
+
+// AuditLog returns the page of the current entry, which contains the
+// users, webhooks and integrations referenced by it.
+func (it *AuditLogIterator) AuditLog() *GuildAuditLog {
+	return it.log
+}
+
+// GuildAuditLogIterator returns an iterator over the audit log of a guild.
+// It can only iterate backward.
+// guildID    : The ID of a Guild.
+// userID     : If provided the log will be filtered for the given ID.
+// actionType : If provided the log will be filtered for given Action Type.
+// opts       : Where to start and stop and the page size.
+func (s *Session) GuildAuditLogIterator(guildID, userID string, actionType int, opts IteratorOptions, options ...RequestOption) *AuditLogIterator {
+	it := &AuditLogIterator{}
+
+	fetch := func(cursor string, forward bool, limit int) ([]interface{}, error) {
+		log, err := s.GuildAuditLog(guildID, userID, cursor, actionType, limit, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		it.log = log
+
+		items := make([]interface{}, len(log.AuditLogEntries))
+		for i, e := range log.AuditLogEntries {
+			items[i] = e
+		}
+		return items, nil
+	}
+
+	id := func(item interface{}) string { return item.(*AuditLogEntry).ID }
+	it.iterator = newIterator(fetch, id, 100, true, false, opts, options)
+	return it
+}
@@ -0,0 +1,154 @@
+package discordgo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// newIteratorTestSession returns a session whose requests are answered by handler.
+func newIteratorTestSession(handler http.HandlerFunc) (*Session, func()) {
+	srv := httptest.NewServer(handler)
+	target, _ := url.Parse(srv.URL)
+
+	s := newRetryTestSession(NewRetryPolicy())
+	s.Client = &http.Client{Transport: rewriteTransport{target}}
+	return s, srv.Close
+}
+
+// serveMessages answers message requests with the messages 1000 to 1249, newest first.
+func serveMessages(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	before, _ := strconv.Atoi(q.Get("before"))
+	after, _ := strconv.Atoi(q.Get("after"))
+
+	messages := []*Message{}
+	if q.Get("after") != "" {
+		for id := after + 1; id < 1250 && len(messages) < limit; id++ {
+			messages = append([]*Message{{ID: strconv.Itoa(id)}}, messages...)
+		}
+	} else {
+		if q.Get("before") == "" {
+			before = 1250
+		}
+		for id := before - 1; id >= 1000 && len(messages) < limit; id-- {
+			messages = append(messages, &Message{ID: strconv.Itoa(id)})
+		}
+	}
+	json.NewEncoder(w).Encode(messages)
+}
+
+func TestChannelMessagesIterator(t *testing.T) {
+	s, done := newIteratorTestSession(serveMessages)
+	defer done()
+
+	it := s.ChannelMessagesIterator("1", IteratorOptions{})
+	expected := 1249
+	for it.Next() {
+		if id := it.Message().ID; id != strconv.Itoa(expected) {
+			t.Fatalf("expected message %d, got %s", expected, id)
+		}
+		expected--
+	}
+	if it.Err() != nil {
+		t.Fatalf("iterator returned error: %v", it.Err())
+	}
+	if expected != 999 {
+		t.Errorf("iteration stopped before message %d", expected)
+	}
+
+	it = s.ChannelMessagesIterator("1", IteratorOptions{Direction: IterateForward, Start: "1100", Limit: 120, PageSize: 50})
+	count := 0
+	for it.Next() {
+		count++
+		if id := it.Message().ID; id != strconv.Itoa(1100+count) {
+			t.Fatalf("expected message %d, got %s", 1100+count, id)
+		}
+	}
+	if count != 120 {
+		t.Errorf("expected 120 messages, got %d", count)
+	}
+}
+
+func TestIteratorContext(t *testing.T) {
+	s, done := newIteratorTestSession(serveMessages)
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := s.ChannelMessagesIterator("1", IteratorOptions{PageSize: 10}, WithContext(ctx))
+	for i := 0; i < 10; i++ {
+		it.Next()
+	}
+	cancel()
+
+	for it.Next() {
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", it.Err())
+	}
+}
+
+func TestIteratorIgnoredCursor(t *testing.T) {
+	var requests int
+	s, done := newIteratorTestSession(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode([]*GuildBan{{User: &User{ID: "1001"}}, {User: &User{ID: "1000"}}})
+	})
+	defer done()
+
+	it := s.GuildBansIterator("1", IteratorOptions{Direction: IterateForward, PageSize: 2})
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if it.Err() != nil {
+		t.Fatalf("iterator returned error: %v", it.Err())
+	}
+	if count != 2 || requests != 2 {
+		t.Errorf("expected 2 bans in 2 requests, got %d in %d", count, requests)
+	}
+}
+
+func TestGuildBansIteratorBackward(t *testing.T) {
+	// Bans before the cursor are returned oldest first.
+	requests := 0
+	s, done := newIteratorTestSession(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		before, _ := strconv.Atoi(r.URL.Query().Get("before"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		bans := []*GuildBan{}
+		for id := before - limit; id < before; id++ {
+			if id >= 1000 {
+				bans = append(bans, &GuildBan{User: &User{ID: strconv.Itoa(id)}})
+			}
+		}
+		json.NewEncoder(w).Encode(bans)
+	})
+	defer done()
+
+	it := s.GuildBansIterator("1", IteratorOptions{Limit: 10})
+	if it.Next() || it.Err() != ErrIteratorStart || requests != 0 {
+		t.Fatalf("expected ErrIteratorStart without a request, got %v after %d requests", it.Err(), requests)
+	}
+
+	it = s.GuildBansIterator("1", IteratorOptions{Start: "1250", Limit: 300, PageSize: 100})
+	expected := 1249
+	for it.Next() {
+		if id := it.Ban().User.ID; id != strconv.Itoa(expected) {
+			t.Fatalf("expected ban %d, got %s", expected, id)
+		}
+		expected--
+	}
+	if it.Err() != nil {
+		t.Fatalf("iterator returned error: %v", it.Err())
+	}
+	if expected != 999 {
+		t.Errorf("iteration stopped before ban %d", expected)
+	}
+}
@@ -0,0 +1,97 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package markdown parses Discord-flavored markdown of message content and
+// renders it as plain text, HTML or escaped markdown.
+//
+//	doc := markdown.Parse(m.Content)
+//	text := markdown.RenderText(doc, markdown.NewStateResolver(s.State, m))
+package markdown
+
+import (
+	"time"
+
+	"github.com/NilPointer-Software/discordgo/formatting"
+)
+
+// NodeType is the type of a Node.
+type NodeType int
+
+// Block contains the known NodeType values
+const (
+	// Document is the root node returned by Parse.
+	Document NodeType = iota
+	Text
+	LineBreak
+	Bold
+	Italic
+	Underline
+	Strikethrough
+	Spoiler
+	InlineCode
+	CodeBlock
+	BlockQuote
+	Header
+	Link
+	UserMention
+	RoleMention
+	ChannelMention
+	Everyone
+	Here
+	CustomEmoji
+	Timestamp
+)
+
+var nodeTypeNames = [...]string{
+	"Document", "Text", "LineBreak", "Bold", "Italic", "Underline", "Strikethrough",
+	"Spoiler", "InlineCode", "CodeBlock", "BlockQuote", "Header", "Link",
+	"UserMention", "RoleMention", "ChannelMention", "Everyone", "Here",
+	"CustomEmoji", "Timestamp",
+}
+
+// String returns the name of the node type.
+func (t NodeType) String() string {
+	if t < 0 || int(t) >= len(nodeTypeNames) {
+		return "Unknown"
+	}
+	return nodeTypeNames[t]
+}
+
+// A Node is a node of the markdown syntax tree. Which fields are set depends on its Type.
+type Node struct {
+	Type NodeType
+
+	// Children of Document, Bold, Italic, Underline, Strikethrough,
+	// Spoiler, BlockQuote, Header and Link nodes.
+	Children []*Node
+
+	// Text is the text of Text nodes, the code of InlineCode and CodeBlock
+	// nodes and the name of CustomEmoji nodes.
+	Text string
+
+	// Language is the language of a CodeBlock, it may be empty.
+	Language string
+
+	// Level is the level of a Header, from 1 to 3.
+	Level int
+
+	// URL is the target of a Link.
+	URL string
+
+	// ID is the ID of a mention or CustomEmoji.
+	ID string
+
+	// Animated is set for animated CustomEmojis.
+	Animated bool
+
+	// Time and Style are set for Timestamps.
+	Time  time.Time
+	Style formatting.TimestampStyle
+
+	// Source is the markdown the node was parsed from.
+	Source string
+}
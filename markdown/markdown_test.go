@@ -0,0 +1,153 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NilPointer-Software/discordgo"
+	"github.com/NilPointer-Software/discordgo/formatting"
+)
+
+// dump returns a compact representation of a syntax tree.
+func dump(nodes []*Node) string {
+	var parts []string
+	for _, n := range nodes {
+		s := n.Type.String()
+		switch n.Type {
+		case Text, InlineCode:
+			s += "(" + n.Text + ")"
+		case CodeBlock:
+			s += "[" + n.Language + "](" + n.Text + ")"
+		case UserMention, RoleMention, ChannelMention:
+			s += "(" + n.ID + ")"
+		case CustomEmoji:
+			s += "(" + n.Text + ":" + n.ID + ")"
+		case Link:
+			s += "[" + n.URL + "]"
+		case Timestamp:
+			s += "(" + string(n.Style) + ")"
+		}
+		if len(n.Children) > 0 {
+			s += "{" + dump(n.Children) + "}"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"plain", "Text(plain)"},
+		{"**bold** and *italic*", "Bold{Text(bold)} Text( and ) Italic{Text(italic)}"},
+		{"__under__ ~~strike~~ ||spoiler||", "Underline{Text(under)} Text( ) Strikethrough{Text(strike)} Text( ) Spoiler{Text(spoiler)}"},
+		{"***both***", "Bold{Italic{Text(both)}}"},
+		{"*a **b** c*", "Italic{Text(a ) Bold{Text(b)} Text( c)}"},
+		{"snake_case_name", "Text(snake_case_name)"},
+		{"_it_", "Italic{Text(it)}"},
+		{"* not italic*", "Text(* not italic*)"},
+		{"**unclosed", "Text(**unclosed)"},
+		{`\*escaped\*`, "Text(*escaped*)"},
+		{"`**code**`", "InlineCode(**code**)"},
+		{"``a ` b``", "InlineCode(a ` b)"},
+		{"```go\nfmt.Println()\n```", "CodeBlock[go](fmt.Println())"},
+		{"```\n> not a quote\n```", "CodeBlock[](> not a quote)"},
+		{"```one line```", "CodeBlock[](one line)"},
+		{"> quote\n> more\nafter", "BlockQuote{Text(quote) LineBreak Text(more)} Text(after)"},
+		{">>> rest\nof message", "BlockQuote{Text(rest) LineBreak Text(of message)}"},
+		{"# Title\ntext", "Header{Text(Title)} Text(text)"},
+		{"#channel", "Text(#channel)"},
+		{"[docs](https://discord.com/developers)", "Link[https://discord.com/developers]{Text(docs)}"},
+		{"[not](a link)", "Text([not](a link))"},
+		{"<@1> <@!2> <@&3> <#4>", "UserMention(1) Text( ) UserMention(2) Text( ) RoleMention(3) Text( ) ChannelMention(4)"},
+		{"@everyone @here", "Everyone Text( ) Here"},
+		{"<:wave:5> <a:dance:6>", "CustomEmoji(wave:5) Text( ) CustomEmoji(dance:6)"},
+		{"<t:1622548800:R> <t:1622548800>", "Timestamp(R) Text( ) Timestamp(f)"},
+		{"**<@1>**", "Bold{UserMention(1)}"},
+	}
+
+	for _, test := range tests {
+		got := dump(Parse(test.content).Children)
+		if got != test.want {
+			t.Errorf("Parse(%q):\n got  %s\n want %s", test.content, got, test.want)
+		}
+	}
+}
+
+type testResolver map[string]string
+
+func (r testResolver) User(id string) string    { return r["user:"+id] }
+func (r testResolver) Role(id string) string    { return r["role:"+id] }
+func (r testResolver) Channel(id string) string { return r["channel:"+id] }
+
+func TestRender(t *testing.T) {
+	r := testResolver{"user:1": "Alice", "role:2": "Mods", "channel:3": "general"}
+	doc := Parse("> **Hi** <@1>, ask <@&2> in <#3> or <#4>\n`<code>` <:wave:5> @everyone")
+
+	text := RenderText(doc, r)
+	if want := "Hi @Alice, ask @Mods in #general or #unknown-channel\n<code> :wave: @everyone"; text != want {
+		t.Errorf("RenderText:\n got  %q\n want %q", text, want)
+	}
+
+	escaped := RenderEscaped(doc, r)
+	if want := "Hi @Alice, ask @Mods in \\#general or \\#unknown-channel\n\\<code\\> :wave: @\u200beveryone"; escaped != want {
+		t.Errorf("RenderEscaped:\n got  %q\n want %q", escaped, want)
+	}
+
+	html := RenderHTML(doc, r)
+	for _, want := range []string{
+		"<blockquote><strong>Hi</strong> <span class=\"mention\">@Alice</span>",
+		"</blockquote><code>&lt;code&gt;</code>",
+		`<img class="emoji" src="https://cdn.discordapp.com/emojis/5.png" alt=":wave:" title=":wave:">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("RenderHTML: expected %q in %q", want, html)
+		}
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Date(2021, 6, 1, 14, 5, 9, 0, time.UTC)
+	tests := map[formatting.TimestampStyle]string{
+		formatting.TimestampShortTime:     "2:05 PM",
+		formatting.TimestampLongTime:      "2:05:09 PM",
+		formatting.TimestampShortDate:     "06/01/2021",
+		formatting.TimestampLongDate:      "June 1, 2021",
+		formatting.TimestampShortDateTime: "June 1, 2021 2:05 PM",
+		formatting.TimestampLongDateTime:  "Tuesday, June 1, 2021 2:05 PM",
+	}
+	for style, want := range tests {
+		if got := FormatTimestamp(ts, style); got != want {
+			t.Errorf("FormatTimestamp(%s) = %q, want %q", style, got, want)
+		}
+	}
+
+	if got := relativeTime(ts, ts.Add(-2*time.Hour-time.Minute)); got != "in 2 hours" {
+		t.Errorf("expected in 2 hours, got %q", got)
+	}
+	if got := relativeTime(ts, ts.Add(24*time.Hour)); got != "1 day ago" {
+		t.Errorf("expected 1 day ago, got %q", got)
+	}
+}
+
+func TestStateResolver(t *testing.T) {
+	state := discordgo.NewState()
+	state.GuildAdd(&discordgo.Guild{
+		ID:       "1",
+		Roles:    []*discordgo.Role{{ID: "2", Name: "Mods"}},
+		Channels: []*discordgo.Channel{{ID: "3", GuildID: "1", Name: "general"}},
+		Members:  []*discordgo.Member{{GuildID: "1", Nick: "Ally", User: &discordgo.User{ID: "4", Username: "alice"}}},
+	})
+
+	m := &discordgo.Message{
+		ChannelID: "3",
+		Content:   "<@4> <@5> <@&2> <#3>",
+		Mentions:  []*discordgo.User{{ID: "5", Username: "bob"}},
+	}
+	if got := RenderText(Parse(m.Content), NewStateResolver(state, m)); got != "@Ally @bob @Mods #general" {
+		t.Errorf("unexpected rendering %q", got)
+	}
+}
@@ -0,0 +1,363 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the markdown parser.
+
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/NilPointer-Software/discordgo/formatting"
+)
+
+// Parse parses message content into a syntax tree. Parsing never fails,
+// markup which isn't closed is kept as text.
+func Parse(content string) *Node {
+	return &Node{Type: Document, Children: parseBlocks(content), Source: content}
+}
+
+// parseBlocks parses block quotes and headers, the lines between them are parsed as inline content.
+func parseBlocks(s string) (nodes []*Node) {
+	for len(s) > 0 {
+		line, rest := cutLine(s)
+
+		if strings.HasPrefix(s, ">>> ") {
+			// The quote continues until the end of the message.
+			return append(nodes, &Node{Type: BlockQuote, Children: parseBlocks(s[4:]), Source: s})
+		}
+
+		if strings.HasPrefix(line, "> ") || line == ">" {
+			var quoted []string
+			start := s
+			for len(s) > 0 {
+				line, rest = cutLine(s)
+				if !strings.HasPrefix(line, "> ") && line != ">" {
+					break
+				}
+				quoted = append(quoted, strings.TrimPrefix(strings.TrimPrefix(line, ">"), " "))
+				s = rest
+			}
+			nodes = append(nodes, &Node{
+				Type:     BlockQuote,
+				Children: parseBlocks(strings.Join(quoted, "\n")),
+				Source:   strings.TrimSuffix(start[:len(start)-len(s)], "\n"),
+			})
+			continue
+		}
+
+		if level := headerLevel(line); level > 0 {
+			nodes = append(nodes, &Node{
+				Type:     Header,
+				Level:    level,
+				Children: parseInline(strings.TrimSpace(line[level+1:])),
+				Source:   line,
+			})
+			s = rest
+			continue
+		}
+
+		// The paragraph ends before the next block, lines in code blocks don't start blocks.
+		end, fences := 0, 0
+		for end < len(s) {
+			line, _ := cutLine(s[end:])
+			if end > 0 && fences%2 == 0 && isBlockStart(line) {
+				break
+			}
+			fences += strings.Count(line, "```")
+			end += len(line)
+			if end < len(s) {
+				end++ // newline
+			}
+		}
+
+		paragraph := s[:end]
+		if end < len(s) {
+			// The newline before the next block is implied by the block.
+			paragraph = strings.TrimSuffix(paragraph, "\n")
+		}
+		nodes = append(nodes, parseInline(paragraph)...)
+		s = s[end:]
+	}
+	return
+}
+
+// cutLine returns the first line of s without its newline and the rest after it.
+func cutLine(s string) (line, rest string) {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+func isBlockStart(line string) bool {
+	return strings.HasPrefix(line, "> ") || line == ">" || headerLevel(line) > 0
+}
+
+// headerLevel returns the level of a header line, or 0 if it isn't one.
+func headerLevel(line string) int {
+	for level := 1; level <= 3; level++ {
+		prefix := strings.Repeat("#", level) + " "
+		if strings.HasPrefix(line, prefix) && strings.TrimSpace(line[len(prefix):]) != "" {
+			return level
+		}
+	}
+	return 0
+}
+
+var (
+	patternUser      = regexp.MustCompile(`^<@!?(\d+)>`)
+	patternRole      = regexp.MustCompile(`^<@&(\d+)>`)
+	patternChannel   = regexp.MustCompile(`^<#(\d+)>`)
+	patternEmoji     = regexp.MustCompile(`^<(a?):(\w{2,32}):(\d+)>`)
+	patternTimestamp = regexp.MustCompile(`^<t:(-?\d{1,13})(?::([tTdDfFR]))?>`)
+	patternLink      = regexp.MustCompile(`^\[([^\[\]\n]+)\]\(<?(https?://[^\s()<>]+)>?\)`)
+	patternLanguage  = regexp.MustCompile(`^[a-zA-Z0-9_+\-.#]+$`)
+)
+
+// delimiters of inline formatting, longer ones have to come first.
+var delimiters = []struct {
+	delim string
+	typ   NodeType
+}{
+	{"||", Spoiler},
+	{"**", Bold},
+	{"__", Underline},
+	{"~~", Strikethrough},
+	{"*", Italic},
+	{"_", Italic},
+}
+
+// parseInline parses inline content.
+func parseInline(s string) (nodes []*Node) {
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, &Node{Type: Text, Text: text.String(), Source: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		if n, end := parseToken(s, i); n != nil {
+			flush()
+			nodes = append(nodes, n)
+			i = end
+			continue
+		}
+
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s) && isEscapable(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+		case c == '\n':
+			flush()
+			nodes = append(nodes, &Node{Type: LineBreak, Source: "\n"})
+			i++
+		default:
+			text.WriteByte(c)
+			i++
+		}
+	}
+
+	flush()
+	return
+}
+
+// isEscapable reports whether a backslash before c escapes it.
+func isEscapable(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || c == '`' || c == '|' || c == '~' || c == '<' || c == '>'
+}
+
+// parseToken parses the token starting at s[i], if there is one.
+func parseToken(s string, i int) (*Node, int) {
+	rest := s[i:]
+	switch rest[0] {
+	case '`':
+		return parseCode(s, i)
+	case '<':
+		return parseEntity(s, i)
+	case '@':
+		if strings.HasPrefix(rest, "@everyone") {
+			return &Node{Type: Everyone, Source: "@everyone"}, i + len("@everyone")
+		}
+		if strings.HasPrefix(rest, "@here") {
+			return &Node{Type: Here, Source: "@here"}, i + len("@here")
+		}
+	case '[':
+		if m := patternLink.FindStringSubmatch(rest); m != nil {
+			return &Node{Type: Link, Children: parseInline(m[1]), URL: m[2], Source: m[0]}, i + len(m[0])
+		}
+	}
+
+	for _, d := range delimiters {
+		if !strings.HasPrefix(rest, d.delim) || !canOpen(s, i, d.delim) {
+			continue
+		}
+
+		start := i + len(d.delim)
+		end := findClosing(s, start, d.delim)
+		if end < 0 {
+			continue
+		}
+		return &Node{Type: d.typ, Children: parseInline(s[start:end]), Source: s[i : end+len(d.delim)]}, end + len(d.delim)
+	}
+
+	return nil, i
+}
+
+// parseCode parses inline code and code blocks.
+func parseCode(s string, i int) (*Node, int) {
+	rest := s[i:]
+	if strings.HasPrefix(rest, "```") {
+		end := strings.Index(rest[3:], "```")
+		if end > 0 {
+			code := rest[3 : 3+end]
+			n := &Node{Type: CodeBlock, Source: rest[:end+6]}
+
+			if nl := strings.IndexByte(code, '\n'); nl >= 0 && patternLanguage.MatchString(code[:nl]) && strings.TrimSpace(code[nl+1:]) != "" {
+				n.Language = code[:nl]
+				code = code[nl+1:]
+			}
+			code = strings.TrimPrefix(code, "\n")
+			code = strings.TrimSuffix(code, "\n")
+			n.Text = code
+			return n, i + end + 6
+		}
+	}
+
+	ticks := 1
+	if strings.HasPrefix(rest, "``") {
+		ticks = 2
+	}
+	delim := rest[:ticks]
+
+	end := strings.Index(rest[ticks:], delim)
+	for end >= 0 && ticks+end+ticks < len(rest) && rest[ticks+end+ticks] == '`' {
+		// The closing delimiter is part of a longer run of backticks.
+		next := strings.Index(rest[ticks+end+1:], delim)
+		if next < 0 {
+			end = -1
+			break
+		}
+		end += 1 + next
+	}
+	if end <= 0 {
+		return nil, i
+	}
+
+	code := rest[ticks : ticks+end]
+	if ticks == 2 {
+		code = strings.TrimPrefix(strings.TrimSuffix(code, " "), " ")
+	}
+	return &Node{Type: InlineCode, Text: code, Source: rest[:end+2*ticks]}, i + end + 2*ticks
+}
+
+// parseEntity parses mentions, custom emojis and timestamps.
+func parseEntity(s string, i int) (*Node, int) {
+	rest := s[i:]
+
+	if m := patternUser.FindStringSubmatch(rest); m != nil {
+		return &Node{Type: UserMention, ID: m[1], Source: m[0]}, i + len(m[0])
+	}
+	if m := patternRole.FindStringSubmatch(rest); m != nil {
+		return &Node{Type: RoleMention, ID: m[1], Source: m[0]}, i + len(m[0])
+	}
+	if m := patternChannel.FindStringSubmatch(rest); m != nil {
+		return &Node{Type: ChannelMention, ID: m[1], Source: m[0]}, i + len(m[0])
+	}
+	if m := patternEmoji.FindStringSubmatch(rest); m != nil {
+		return &Node{Type: CustomEmoji, Animated: m[1] == "a", Text: m[2], ID: m[3], Source: m[0]}, i + len(m[0])
+	}
+	if m := patternTimestamp.FindStringSubmatch(rest); m != nil {
+		sec, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, i
+		}
+
+		style := formatting.TimestampStyle(m[2])
+		if style == "" {
+			style = formatting.TimestampShortDateTime
+		}
+		return &Node{Type: Timestamp, Time: time.Unix(sec, 0), Style: style, Source: m[0]}, i + len(m[0])
+	}
+
+	return nil, i
+}
+
+// canOpen reports whether delim at s[i] can open formatting.
+func canOpen(s string, i int, delim string) bool {
+	after := i + len(delim)
+	if after >= len(s) {
+		return false
+	}
+
+	switch delim {
+	case "*":
+		// "* item" isn't emphasis.
+		return !unicode.IsSpace(rune(s[after]))
+	case "_":
+		// Underscores in words like snake_case aren't emphasis.
+		return i == 0 || !isWordByte(s[i-1])
+	}
+	return true
+}
+
+// findClosing returns the index of the delimiter closing formatting which
+// starts at s[start], or -1 if it isn't closed. Code and escaped characters
+// are skipped.
+func findClosing(s string, start int, delim string) int {
+	for k := start; k < len(s); {
+		switch {
+		case s[k] == '\\' && k+1 < len(s) && isEscapable(s[k+1]):
+			k += 2
+			continue
+		case s[k] == '`':
+			if n, end := parseCode(s, k); n != nil {
+				k = end
+				continue
+			}
+		case len(delim) == 1 && strings.HasPrefix(s[k:], delim+delim):
+			// Skip nested formatting with the doubled delimiter, e.g. bold inside of italics.
+			if end := findClosing(s, k+2, delim+delim); end > 0 {
+				k = end + 2
+				continue
+			}
+		}
+
+		if k > start && strings.HasPrefix(s[k:], delim) && canClose(s, k, delim) {
+			// The last delimiter of a run closes, so ***a*** is bold italics.
+			for len(delim) == 2 && k+2 < len(s) && s[k+2] == delim[0] {
+				k++
+			}
+			return k
+		}
+		k++
+	}
+	return -1
+}
+
+// canClose reports whether delim at s[k] can close formatting.
+func canClose(s string, k int, delim string) bool {
+	switch delim {
+	case "*":
+		return !unicode.IsSpace(rune(s[k-1]))
+	case "_":
+		after := k + 1
+		return after >= len(s) || !isWordByte(s[after])
+	}
+	return true
+}
+
+func isWordByte(c byte) bool {
+	return c >= utf8.RuneSelf || c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
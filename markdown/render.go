@@ -0,0 +1,249 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the renderers of syntax trees.
+
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/NilPointer-Software/discordgo"
+	"github.com/NilPointer-Software/discordgo/formatting"
+)
+
+// A Resolver resolves the names of mentioned users, roles and channels.
+// It returns an empty string for entities it doesn't know.
+type Resolver interface {
+	User(id string) string
+	Role(id string) string
+	Channel(id string) string
+}
+
+// RenderText renders a node as plain text, without formatting.
+// Mentions are replaced with the names returned by r, which may be nil,
+// and timestamps are formatted in UTC.
+func RenderText(n *Node, r Resolver) string {
+	var b strings.Builder
+	renderText(&b, n, r)
+	return b.String()
+}
+
+func renderText(b *strings.Builder, n *Node, r Resolver) {
+	switch n.Type {
+	case Text, InlineCode, CodeBlock:
+		b.WriteString(n.Text)
+	case LineBreak:
+		b.WriteByte('\n')
+	case Link:
+		text := RenderText(&Node{Type: Document, Children: n.Children}, r)
+		b.WriteString(text)
+		if text != n.URL {
+			b.WriteString(" (" + n.URL + ")")
+		}
+	case UserMention, RoleMention, ChannelMention, Everyone, Here:
+		b.WriteString(mentionText(n, r))
+	case CustomEmoji:
+		b.WriteString(":" + n.Text + ":")
+	case Timestamp:
+		b.WriteString(FormatTimestamp(n.Time, n.Style))
+	default:
+		for i, c := range n.Children {
+			renderText(b, c, r)
+			if isBlock(c) && i < len(n.Children)-1 {
+				b.WriteByte('\n')
+			}
+		}
+	}
+}
+
+// RenderEscaped renders a node as markdown which shows the plain text of
+// the node when it is sent to Discord. Formatting is escaped and mentions
+// are replaced with names, so sending it doesn't mention anyone.
+func RenderEscaped(n *Node, r Resolver) string {
+	return formatting.Escape(RenderText(n, r))
+}
+
+// RenderHTML renders a node as HTML. Mentions are replaced with the names
+// returned by r, which may be nil, and timestamps are formatted in UTC.
+// Elements have the classes mention, spoiler and emoji, which can be styled.
+func RenderHTML(n *Node, r Resolver) string {
+	var b strings.Builder
+	renderHTML(&b, n, r)
+	return b.String()
+}
+
+func renderHTML(b *strings.Builder, n *Node, r Resolver) {
+	children := func() {
+		for _, c := range n.Children {
+			renderHTML(b, c, r)
+		}
+	}
+
+	switch n.Type {
+	case Text:
+		b.WriteString(html.EscapeString(n.Text))
+	case LineBreak:
+		b.WriteString("<br>")
+	case Bold:
+		b.WriteString("<strong>")
+		children()
+		b.WriteString("</strong>")
+	case Italic:
+		b.WriteString("<em>")
+		children()
+		b.WriteString("</em>")
+	case Underline:
+		b.WriteString("<u>")
+		children()
+		b.WriteString("</u>")
+	case Strikethrough:
+		b.WriteString("<s>")
+		children()
+		b.WriteString("</s>")
+	case Spoiler:
+		b.WriteString(`<span class="spoiler">`)
+		children()
+		b.WriteString("</span>")
+	case InlineCode:
+		b.WriteString("<code>" + html.EscapeString(n.Text) + "</code>")
+	case CodeBlock:
+		b.WriteString("<pre><code")
+		if n.Language != "" {
+			b.WriteString(` class="language-` + html.EscapeString(n.Language) + `"`)
+		}
+		b.WriteString(">" + html.EscapeString(n.Text) + "</code></pre>")
+	case BlockQuote:
+		b.WriteString("<blockquote>")
+		children()
+		b.WriteString("</blockquote>")
+	case Header:
+		fmt.Fprintf(b, "<h%d>", n.Level)
+		children()
+		fmt.Fprintf(b, "</h%d>", n.Level)
+	case Link:
+		b.WriteString(`<a href="` + html.EscapeString(n.URL) + `">`)
+		children()
+		b.WriteString("</a>")
+	case UserMention, RoleMention, ChannelMention, Everyone, Here:
+		b.WriteString(`<span class="mention">` + html.EscapeString(mentionText(n, r)) + "</span>")
+	case CustomEmoji:
+		URL, _ := discordgo.CDNEmoji(n.ID, n.Animated, "", 0)
+		fmt.Fprintf(b, `<img class="emoji" src="%s" alt=":%s:" title=":%s:">`,
+			html.EscapeString(URL), html.EscapeString(n.Text), html.EscapeString(n.Text))
+	case Timestamp:
+		fmt.Fprintf(b, `<time datetime="%s">%s</time>`, n.Time.UTC().Format(time.RFC3339), html.EscapeString(FormatTimestamp(n.Time, n.Style)))
+	default:
+		children()
+	}
+}
+
+// isBlock reports whether a node is rendered on its own lines.
+func isBlock(n *Node) bool {
+	return n.Type == BlockQuote || n.Type == Header
+}
+
+// mentionText returns the text of a mention.
+func mentionText(n *Node, r Resolver) string {
+	var name string
+	switch n.Type {
+	case Everyone:
+		return "@everyone"
+	case Here:
+		return "@here"
+	case UserMention:
+		if r != nil {
+			name = r.User(n.ID)
+		}
+		if name == "" {
+			name = "unknown-user"
+		}
+		return "@" + name
+	case RoleMention:
+		if r != nil {
+			name = r.Role(n.ID)
+		}
+		if name == "" {
+			name = "unknown-role"
+		}
+		return "@" + name
+	default:
+		if r != nil {
+			name = r.Channel(n.ID)
+		}
+		if name == "" {
+			name = "unknown-channel"
+		}
+		return "#" + name
+	}
+}
+
+// FormatTimestamp formats t like Discord shows <t:unix:style> timestamps, in UTC.
+func FormatTimestamp(t time.Time, style formatting.TimestampStyle) string {
+	t = t.UTC()
+	switch style {
+	case formatting.TimestampShortTime:
+		return t.Format("3:04 PM")
+	case formatting.TimestampLongTime:
+		return t.Format("3:04:05 PM")
+	case formatting.TimestampShortDate:
+		return t.Format("01/02/2006")
+	case formatting.TimestampLongDate:
+		return t.Format("January 2, 2006")
+	case formatting.TimestampLongDateTime:
+		return t.Format("Monday, January 2, 2006 3:04 PM")
+	case formatting.TimestampRelative:
+		return relativeTime(t, time.Now())
+	default:
+		return t.Format("January 2, 2006 3:04 PM")
+	}
+}
+
+// relativeTime returns the time from now to t, e.g. "in 2 hours" or "3 days ago".
+func relativeTime(t, now time.Time) string {
+	d := t.Sub(now)
+	future := d > 0
+	if !future {
+		d = -d
+	}
+
+	units := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"year", 365 * 24 * time.Hour},
+		{"month", 30 * 24 * time.Hour},
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	}
+
+	text := "now"
+	for _, u := range units {
+		if n := int(d / u.d); n > 0 {
+			if n == 1 {
+				text = "1 " + u.name
+			} else {
+				text = fmt.Sprintf("%d %ss", n, u.name)
+			}
+			break
+		}
+	}
+
+	switch {
+	case text == "now":
+		return text
+	case future:
+		return "in " + text
+	default:
+		return text + " ago"
+	}
+}
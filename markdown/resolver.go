@@ -0,0 +1,83 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains a Resolver backed by the State.
+
+package markdown
+
+import "github.com/NilPointer-Software/discordgo"
+
+// StateResolver is a Resolver which looks up entities in the State.
+// Users are shown with their nickname in the guild, if they have one.
+type StateResolver struct {
+	State   *discordgo.State
+	GuildID string
+
+	// Users are used for users which aren't members in the State,
+	// e.g. the mentions of a message.
+	Users []*discordgo.User
+}
+
+// NewStateResolver returns a StateResolver for the content of a message.
+// state : The State of a Session, may be nil.
+// m     : The message whose content is rendered.
+func NewStateResolver(state *discordgo.State, m *discordgo.Message) *StateResolver {
+	r := &StateResolver{State: state, GuildID: m.GuildID, Users: m.Mentions}
+	if r.GuildID == "" && state != nil {
+		if c, err := state.Channel(m.ChannelID); err == nil {
+			r.GuildID = c.GuildID
+		}
+	}
+	return r
+}
+
+// User implements Resolver.
+func (r *StateResolver) User(id string) string {
+	if r.State != nil && r.GuildID != "" {
+		if member, err := r.State.Member(r.GuildID, id); err == nil {
+			if member.Nick != "" {
+				return member.Nick
+			}
+			if member.User != nil {
+				return member.User.Username
+			}
+		}
+	}
+
+	for _, u := range r.Users {
+		if u.ID == id {
+			return u.Username
+		}
+	}
+	return ""
+}
+
+// Role implements Resolver.
+func (r *StateResolver) Role(id string) string {
+	if r.State == nil || r.GuildID == "" {
+		return ""
+	}
+
+	role, err := r.State.Role(r.GuildID, id)
+	if err != nil {
+		return ""
+	}
+	return role.Name
+}
+
+// Channel implements Resolver.
+func (r *StateResolver) Channel(id string) string {
+	if r.State == nil {
+		return ""
+	}
+
+	channel, err := r.State.Channel(id)
+	if err != nil {
+		return ""
+	}
+	return channel.Name
+}
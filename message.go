@@ -146,10 +146,28 @@ type Message struct {
 }
 
 // File stores info about files you e.g. send in messages.
+// Files are streamed to Discord without being buffered in memory. If a
+// request has to be retried, Reader is seeked back to where it started or
+// Open is called again. Files with other readers can't be sent again, the
+// retry fails with ErrFileNotRewindable.
 type File struct {
 	Name        string
 	ContentType string
 	Reader      io.Reader
+
+	// Open, if set, is called to open the file for every attempt to send it
+	// and Reader is ignored. The returned reader is closed after it was sent.
+	Open func() (io.ReadCloser, error)
+
+	// Size is the size of the file in bytes, used to check the upload limit
+	// before anything is sent. It is determined from Reader if it is not set.
+	Size int64
+
+	// Description is the alt text of the attachment.
+	Description string
+
+	// Spoiler marks the attachment as spoiler by prefixing its name with "SPOILER_".
+	Spoiler bool
 }
 
 // MessageSend stores all parameters you can send with ChannelMessageSendComplex.
@@ -175,6 +193,11 @@ type MessageEdit struct {
 	Flags      int              `json:"flags,omitempty"`
 	Components *[]Component     `json:"components,omitempty"`
 
+	// Files are added to the message.
+	Files []*File `json:"-"`
+	// Attachments are the existing attachments to keep, if set all others are removed.
+	Attachments *[]*MessageAttachment `json:"attachments,omitempty"`
+
 	ID      string `json:"-"`
 	Channel string `json:"-"`
 }
@@ -204,13 +227,15 @@ func (m *MessageEdit) SetEmbeds(embeds []*MessageEmbed) *MessageEdit {
 
 // A MessageAttachment stores data for message attachments.
 type MessageAttachment struct {
-	ID       string `json:"id"`
-	Filename string `json:"filename"`
-	Size     int    `json:"size"`
-	URL      string `json:"url"`
-	ProxyURL string `json:"proxy_url"`
-	Height   int    `json:"height"`
-	Width    int    `json:"width"`
+	ID          string `json:"id"`
+	Filename    string `json:"filename"`
+	Description string `json:"description,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int    `json:"size"`
+	URL         string `json:"url"`
+	ProxyURL    string `json:"proxy_url"`
+	Height      int    `json:"height"`
+	Width       int    `json:"width"`
 }
 
 // MessageEmbedFooter is a part of a MessageEmbed struct.
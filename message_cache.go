@@ -0,0 +1,295 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the message cache, which can replace the messages
+// kept in the channels of the State.
+
+package discordgo
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MessageCachePolicy limits the messages cached in a channel.
+type MessageCachePolicy struct {
+	// Disabled disables caching messages.
+	Disabled bool
+
+	// MaxMessages is the maximum number of messages cached per channel, the
+	// oldest messages are evicted first. 0 means no limit.
+	MaxMessages int
+
+	// TTL is how long messages are cached after they were added or updated.
+	// 0 keeps them until they are evicted.
+	TTL time.Duration
+}
+
+// messageOverhead is the estimated memory used by a message without its
+// variable length content.
+const messageOverhead = 512
+
+// messageCacheEntry is a cached message, it's an element of the LRU list and
+// of the list of its channel.
+type messageCacheEntry struct {
+	message *Message
+	size    int
+	expires time.Time
+
+	lru     *list.Element
+	channel *list.Element
+}
+
+// A MessageCache caches messages by ID. Messages are limited by the policy
+// of their channel, or else their guild, or else the default policy. When
+// the cache exceeds its memory limit, the least recently used messages are
+// evicted. Lookups, updates and removals take constant time.
+//
+// A MessageCache is safe for concurrent use.
+type MessageCache struct {
+	mu sync.Mutex
+
+	maxBytes        int
+	policy          MessageCachePolicy
+	guildPolicies   map[string]MessageCachePolicy
+	channelPolicies map[string]MessageCachePolicy
+
+	entries  map[string]*messageCacheEntry
+	channels map[string]*list.List
+	// lru holds the entries with the most recently used at the front.
+	lru  *list.List
+	size int
+
+	// now returns the current time, it's replaced in tests.
+	now func() time.Time
+}
+
+// NewMessageCache returns an empty MessageCache.
+// policy   : The default policy of channels.
+// maxBytes : The estimated memory limit of the cached messages, 0 for no limit.
+//
+//	s.State.MessageCache = discordgo.NewMessageCache(discordgo.MessageCachePolicy{MaxMessages: 100, TTL: time.Hour}, 64<<20)
+func NewMessageCache(policy MessageCachePolicy, maxBytes int) *MessageCache {
+	return &MessageCache{
+		maxBytes:        maxBytes,
+		policy:          policy,
+		guildPolicies:   make(map[string]MessageCachePolicy),
+		channelPolicies: make(map[string]MessageCachePolicy),
+		entries:         make(map[string]*messageCacheEntry),
+		channels:        make(map[string]*list.List),
+		lru:             list.New(),
+		now:             time.Now,
+	}
+}
+
+// SetGuildPolicy sets the policy of the channels of a guild, which applies
+// to messages added afterwards.
+func (c *MessageCache) SetGuildPolicy(guildID string, policy MessageCachePolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.guildPolicies[guildID] = policy
+}
+
+// SetChannelPolicy sets the policy of a channel, which overrides the policy
+// of its guild and applies to messages added afterwards.
+func (c *MessageCache) SetChannelPolicy(channelID string, policy MessageCachePolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.channelPolicies[channelID] = policy
+}
+
+// policyOf returns the policy of the channel of a message.
+func (c *MessageCache) policyOf(message *Message) MessageCachePolicy {
+	if policy, ok := c.channelPolicies[message.ChannelID]; ok {
+		return policy
+	}
+	if policy, ok := c.guildPolicies[message.GuildID]; ok {
+		return policy
+	}
+	return c.policy
+}
+
+// Add adds a message to the cache, or replaces it if it's cached.
+func (c *MessageCache) Add(message *Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	policy := c.policyOf(message)
+	e, ok := c.entries[message.ID]
+	if ok && e.message.ChannelID != message.ChannelID {
+		c.remove(e)
+		ok = false
+	}
+	if policy.Disabled {
+		if ok {
+			c.remove(e)
+		}
+		return
+	}
+
+	if ok {
+		c.size -= e.size
+		e.message = message
+		c.lru.MoveToFront(e.lru)
+	} else {
+		e = &messageCacheEntry{message: message}
+		e.lru = c.lru.PushFront(e)
+
+		messages, ok := c.channels[message.ChannelID]
+		if !ok {
+			messages = list.New()
+			c.channels[message.ChannelID] = messages
+		}
+		e.channel = messages.PushBack(e)
+		c.entries[message.ID] = e
+	}
+
+	e.size = messageSize(message)
+	c.size += e.size
+	e.expires = time.Time{}
+	if policy.TTL > 0 {
+		e.expires = c.now().Add(policy.TTL)
+	}
+
+	if policy.MaxMessages > 0 {
+		messages := c.channels[message.ChannelID]
+		for messages.Len() > policy.MaxMessages {
+			c.remove(messages.Front().Value.(*messageCacheEntry))
+		}
+	}
+	for c.maxBytes > 0 && c.size > c.maxBytes && c.lru.Len() > 0 {
+		c.remove(c.lru.Back().Value.(*messageCacheEntry))
+	}
+}
+
+// Get returns a cached message by ID and marks it as recently used.
+func (c *MessageCache) Get(messageID string) (*Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[messageID]
+	if !ok {
+		return nil, false
+	}
+	if c.expired(e) {
+		c.remove(e)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(e.lru)
+	return e.message, true
+}
+
+// Remove removes a message from the cache and returns whether it was cached.
+func (c *MessageCache) Remove(messageID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[messageID]
+	if ok {
+		c.remove(e)
+	}
+	return ok
+}
+
+// RemoveChannel removes the messages of a channel from the cache.
+func (c *MessageCache) RemoveChannel(channelID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	messages, ok := c.channels[channelID]
+	if !ok {
+		return
+	}
+	for messages.Len() > 0 {
+		c.remove(messages.Front().Value.(*messageCacheEntry))
+	}
+}
+
+// Messages returns the cached messages of a channel, from the oldest to the
+// newest, without marking them as recently used.
+func (c *MessageCache) Messages(channelID string) []*Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	messages, ok := c.channels[channelID]
+	if !ok {
+		return nil
+	}
+
+	result := make([]*Message, 0, messages.Len())
+	for el := messages.Front(); el != nil; el = el.Next() {
+		if e := el.Value.(*messageCacheEntry); !c.expired(e) {
+			result = append(result, e.message)
+		}
+	}
+	return result
+}
+
+// Purge removes the expired messages from the cache. Expired messages are
+// never returned, but they are only removed when they are looked up, evicted
+// or purged.
+func (c *MessageCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.entries {
+		if c.expired(e) {
+			c.remove(e)
+		}
+	}
+}
+
+// Len returns the number of cached messages.
+func (c *MessageCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// Size returns the estimated memory used by the cached messages in bytes.
+func (c *MessageCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
+
+func (c *MessageCache) expired(e *messageCacheEntry) bool {
+	return !e.expires.IsZero() && !c.now().Before(e.expires)
+}
+
+func (c *MessageCache) remove(e *messageCacheEntry) {
+	delete(c.entries, e.message.ID)
+	c.lru.Remove(e.lru)
+	c.size -= e.size
+
+	messages := c.channels[e.message.ChannelID]
+	messages.Remove(e.channel)
+	if messages.Len() == 0 {
+		delete(c.channels, e.message.ChannelID)
+	}
+}
+
+// messageSize estimates the memory used by a message.
+func messageSize(m *Message) int {
+	size := messageOverhead + len(m.Content) + 64*len(m.Mentions)
+	for _, a := range m.Attachments {
+		size += 64 + len(a.ID) + len(a.Filename) + len(a.Description) + len(a.URL) + len(a.ProxyURL)
+	}
+	for _, e := range m.Embeds {
+		size += 128 + len(e.Title) + len(e.Description) + len(e.URL)
+		for _, f := range e.Fields {
+			size += len(f.Name) + len(f.Value)
+		}
+	}
+	return size
+}
@@ -0,0 +1,120 @@
+package discordgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMessageCachePolicies(t *testing.T) {
+	c := NewMessageCache(MessageCachePolicy{MaxMessages: 2}, 0)
+	c.SetGuildPolicy("1", MessageCachePolicy{Disabled: true})
+	c.SetChannelPolicy("3", MessageCachePolicy{MaxMessages: 1})
+
+	for _, m := range []*Message{
+		{ID: "10", ChannelID: "2"},
+		{ID: "11", ChannelID: "2"},
+		{ID: "12", ChannelID: "2"},
+		{ID: "13", ChannelID: "3", GuildID: "1"},
+		{ID: "14", ChannelID: "3", GuildID: "1"},
+		{ID: "15", ChannelID: "4", GuildID: "1"},
+	} {
+		c.Add(m)
+	}
+
+	if _, ok := c.Get("10"); ok {
+		t.Errorf("expected the oldest message of channel 2 to be evicted")
+	}
+	if ms := c.Messages("2"); len(ms) != 2 || ms[0].ID != "11" || ms[1].ID != "12" {
+		t.Errorf("unexpected messages of channel 2 %v", ms)
+	}
+	if ms := c.Messages("3"); len(ms) != 1 || ms[0].ID != "14" {
+		t.Errorf("unexpected messages of channel 3 %v", ms)
+	}
+	if _, ok := c.Get("15"); ok {
+		t.Errorf("expected the message of guild 1 not to be cached")
+	}
+
+	c.RemoveChannel("2")
+	if c.Len() != 1 || c.Size() != messageOverhead {
+		t.Errorf("unexpected cache of %d messages, %d bytes", c.Len(), c.Size())
+	}
+}
+
+func TestMessageCacheEviction(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := NewMessageCache(MessageCachePolicy{TTL: time.Minute}, 3*messageOverhead)
+	c.now = func() time.Time { return now }
+
+	c.Add(&Message{ID: "10", ChannelID: "2"})
+	c.Add(&Message{ID: "11", ChannelID: "2"})
+	c.Add(&Message{ID: "12", ChannelID: "2"})
+	c.Get("10")
+	c.Add(&Message{ID: "13", ChannelID: "2"})
+
+	if _, ok := c.Get("11"); ok {
+		t.Errorf("expected the least recently used message to be evicted")
+	}
+	if _, ok := c.Get("10"); !ok {
+		t.Errorf("expected the recently used message to be cached")
+	}
+
+	now = now.Add(30 * time.Second)
+	c.Add(&Message{ID: "12", ChannelID: "2", Content: "edited"})
+	now = now.Add(45 * time.Second)
+	if _, ok := c.Get("10"); ok {
+		t.Errorf("expected the message to expire")
+	}
+	if m, ok := c.Get("12"); !ok || m.Content != "edited" {
+		t.Errorf("expected the updated message to be cached, got %+v", m)
+	}
+
+	c.Purge()
+	if c.Len() != 1 || c.Size() != messageOverhead+len("edited") {
+		t.Errorf("unexpected cache of %d messages, %d bytes", c.Len(), c.Size())
+	}
+}
+
+func TestStateMessageCache(t *testing.T) {
+	state := NewState()
+	state.MessageCache = NewMessageCache(MessageCachePolicy{}, 0)
+	se := &Session{StateEnabled: true}
+
+	update := &MessageUpdate{Message: &Message{ID: "10", ChannelID: "2", Content: "b"}}
+	for _, e := range []interface{}{
+		stateTestReady(),
+		&MessageCreate{&Message{ID: "10", ChannelID: "2", Content: "a"}},
+		&MessageCreate{&Message{ID: "11", ChannelID: "5", Content: "unknown channel"}},
+		update,
+	} {
+		state.OnInterface(se, e)
+	}
+
+	if update.BeforeUpdate == nil || update.BeforeUpdate.Content != "a" {
+		t.Errorf("unexpected message before the update %+v", update.BeforeUpdate)
+	}
+	if m, err := state.Message("2", "10"); err != nil || m.Content != "b" {
+		t.Errorf("unexpected message %+v, %v", m, err)
+	}
+	if state.MessageCache.Len() != 1 || len(state.Guilds[0].Channels[0].Messages) != 0 {
+		t.Errorf("expected the message to be kept in the cache only")
+	}
+
+	if err := state.OnInterface(se, &MessageDelete{Message: &Message{ID: "10", ChannelID: "2"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := state.Message("2", "10"); err != ErrStateNotFound {
+		t.Errorf("expected the message to be deleted, got %v", err)
+	}
+
+	for _, e := range []interface{}{
+		&MessageCreate{&Message{ID: "12", ChannelID: "2", Content: "c"}},
+		&GuildDelete{Guild: &Guild{ID: "1"}},
+	} {
+		if err := state.OnInterface(se, e); err != nil {
+			t.Fatalf("%T: %v", e, err)
+		}
+	}
+	if state.MessageCache.Len() != 0 {
+		t.Errorf("expected the messages of the removed guild to be purged, got %d", state.MessageCache.Len())
+	}
+}
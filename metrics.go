@@ -0,0 +1,175 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains a RequestHook which collects metrics about REST requests.
+
+package discordgo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsCollector is a RequestHook which counts REST requests per route,
+// method and status code, and measures their latency and rate limit waits.
+// Add it to Session.RequestHooks to enable it.
+type MetricsCollector struct {
+	sync.Mutex
+
+	requests map[requestMetricsKey]*requestMetrics
+	waits    map[routeKey]*requestMetrics
+}
+
+type routeKey struct {
+	method string
+	route  string
+}
+
+type requestMetricsKey struct {
+	routeKey
+	status string
+}
+
+type requestMetrics struct {
+	count int64
+	total time.Duration
+}
+
+// NewMetricsCollector returns a new, empty MetricsCollector.
+func NewMetricsCollector() *MetricsCollector {
+	return &MetricsCollector{
+		requests: make(map[requestMetricsKey]*requestMetrics),
+		waits:    make(map[routeKey]*requestMetrics),
+	}
+}
+
+// BeforeRequest implements RequestHook.
+func (m *MetricsCollector) BeforeRequest(*RequestInfo) {}
+
+// AfterResponse implements RequestHook.
+func (m *MetricsCollector) AfterResponse(info *ResponseInfo) {
+	status := "error"
+	if info.StatusCode != 0 {
+		status = strconv.Itoa(info.StatusCode)
+	}
+	key := requestMetricsKey{routeKey{info.Method, info.Route}, status}
+
+	m.Lock()
+	defer m.Unlock()
+
+	rm, ok := m.requests[key]
+	if !ok {
+		rm = &requestMetrics{}
+		m.requests[key] = rm
+	}
+	rm.count++
+	rm.total += info.Latency
+}
+
+// RateLimitWait implements RequestHook.
+func (m *MetricsCollector) RateLimitWait(info *RateLimitWaitInfo) {
+	key := routeKey{info.Method, info.Route}
+
+	m.Lock()
+	defer m.Unlock()
+
+	rm, ok := m.waits[key]
+	if !ok {
+		rm = &requestMetrics{}
+		m.waits[key] = rm
+	}
+	rm.count++
+	rm.total += info.Wait
+}
+
+// Requests returns the number of requests sent to a route with the given method.
+// method : The HTTP method, e.g. "GET".
+// route  : The route template, see RouteTemplate.
+func (m *MetricsCollector) Requests(method, route string) (count int64) {
+	m.Lock()
+	defer m.Unlock()
+
+	for key, rm := range m.requests {
+		if key.method == method && key.route == route {
+			count += rm.count
+		}
+	}
+	return
+}
+
+// WritePrometheus writes all metrics to w in the Prometheus text exposition format.
+func (m *MetricsCollector) WritePrometheus(w io.Writer) error {
+	m.Lock()
+	defer m.Unlock()
+
+	bw := bufio.NewWriter(w)
+
+	requestKeys := make([]requestMetricsKey, 0, len(m.requests))
+	for key := range m.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		a, b := requestKeys[i], requestKeys[j]
+		if a.routeKey != b.routeKey {
+			return a.routeKey.less(b.routeKey)
+		}
+		return a.status < b.status
+	})
+
+	fmt.Fprintln(bw, "# HELP discordgo_requests_total Number of REST requests sent to Discord.")
+	fmt.Fprintln(bw, "# TYPE discordgo_requests_total counter")
+	for _, key := range requestKeys {
+		fmt.Fprintf(bw, "discordgo_requests_total{%s,status=\"%s\"} %d\n", key.labels(), key.status, m.requests[key].count)
+	}
+
+	fmt.Fprintln(bw, "# HELP discordgo_request_duration_seconds Latency of REST requests sent to Discord.")
+	fmt.Fprintln(bw, "# TYPE discordgo_request_duration_seconds summary")
+	for _, key := range requestKeys {
+		rm := m.requests[key]
+		fmt.Fprintf(bw, "discordgo_request_duration_seconds_sum{%s,status=\"%s\"} %g\n", key.labels(), key.status, rm.total.Seconds())
+		fmt.Fprintf(bw, "discordgo_request_duration_seconds_count{%s,status=\"%s\"} %d\n", key.labels(), key.status, rm.count)
+	}
+
+	waitKeys := make([]routeKey, 0, len(m.waits))
+	for key := range m.waits {
+		waitKeys = append(waitKeys, key)
+	}
+	sort.Slice(waitKeys, func(i, j int) bool { return waitKeys[i].less(waitKeys[j]) })
+
+	fmt.Fprintln(bw, "# HELP discordgo_ratelimit_wait_seconds Time REST requests spent waiting for rate limits.")
+	fmt.Fprintln(bw, "# TYPE discordgo_ratelimit_wait_seconds summary")
+	for _, key := range waitKeys {
+		rm := m.waits[key]
+		fmt.Fprintf(bw, "discordgo_ratelimit_wait_seconds_sum{%s} %g\n", key.labels(), rm.total.Seconds())
+		fmt.Fprintf(bw, "discordgo_ratelimit_wait_seconds_count{%s} %d\n", key.labels(), rm.count)
+	}
+
+	return bw.Flush()
+}
+
+func (k routeKey) less(o routeKey) bool {
+	if k.route != o.route {
+		return k.route < o.route
+	}
+	return k.method < o.method
+}
+
+func (k routeKey) labels() string {
+	return "method=\"" + escapeLabel(k.method) + "\",route=\"" + escapeLabel(k.route) + "\""
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
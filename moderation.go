@@ -0,0 +1,315 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the role hierarchy and the checks whether a member can
+// moderate another member or a role, so moderation fails before Discord
+// rejects it with a 403.
+
+package discordgo
+
+import "errors"
+
+// ErrModeration matches all ModerationErrors with errors.Is.
+var ErrModeration = errors.New("moderation not allowed")
+
+// Actions of ModerationErrors.
+const (
+	ModerationKick         = "kick"
+	ModerationBan          = "ban"
+	ModerationManageRole   = "manage role"
+	ModerationEditNickname = "edit nickname"
+)
+
+// Reasons of ModerationErrors.
+const (
+	ModerationMissingPermission = "missing permission"
+	ModerationTargetOwner       = "the target is the owner of the guild"
+	ModerationTargetSelf        = "the target is the actor"
+	ModerationTargetHigher      = "the target is not below the actor in the role hierarchy"
+	ModerationRoleManaged       = "the role is managed by an integration"
+	ModerationRoleEveryone      = "the role is the @everyone role"
+)
+
+// A ModerationError is returned when a member can't moderate a member or role.
+type ModerationError struct {
+	// Action is the checked action, e.g. ModerationKick.
+	Action string
+
+	// Reason describes why the action isn't allowed, e.g. ModerationTargetOwner.
+	Reason string
+
+	// Permission is the missing permission if Reason is ModerationMissingPermission.
+	Permission Permissions
+}
+
+// Error implements the error interface.
+func (e *ModerationError) Error() string {
+	if e.Reason == ModerationMissingPermission {
+		return "cannot " + e.Action + ": " + e.Reason + " " + e.Permission.String()
+	}
+	return "cannot " + e.Action + ": " + e.Reason
+}
+
+// Is makes errors.Is(err, ErrModeration) true.
+func (e *ModerationError) Is(target error) bool {
+	return target == ErrModeration
+}
+
+// roleAbove returns true if role a is above role b in the hierarchy.
+// Roles with the same position are ordered by their IDs, older roles are above.
+func roleAbove(a, b *Role) bool {
+	if a.Position != b.Position {
+		return a.Position > b.Position
+	}
+	return SnowflakeLess(a.ID, b.ID)
+}
+
+// HighestRole returns the highest role of a member in the guild, or nil
+// if the member has no roles except @everyone.
+func (g *Guild) HighestRole(member *Member) (highest *Role) {
+	for _, role := range g.Roles {
+		for _, roleID := range member.Roles {
+			if role.ID == roleID && (highest == nil || roleAbove(role, highest)) {
+				highest = role
+			}
+		}
+	}
+	return
+}
+
+// everyoneRole returns the @everyone role of the guild.
+func (g *Guild) everyoneRole() *Role {
+	for _, role := range g.Roles {
+		if role.ID == g.ID {
+			return role
+		}
+	}
+	return &Role{ID: g.ID}
+}
+
+// CompareMembers compares the members in the role hierarchy of the guild.
+// It returns a positive number if a is above b, a negative number if a is
+// below b and 0 if they are equal. The owner of the guild is above everyone.
+func (g *Guild) CompareMembers(a, b *Member) int {
+	switch {
+	case a.User.ID == b.User.ID:
+		return 0
+	case a.User.ID == g.OwnerID:
+		return 1
+	case b.User.ID == g.OwnerID:
+		return -1
+	}
+	return g.compareRole(g.HighestRole(a), g.HighestRole(b))
+}
+
+// compareRole compares roles in the hierarchy, nil is the @everyone role.
+func (g *Guild) compareRole(a, b *Role) int {
+	if a == nil {
+		a = g.everyoneRole()
+	}
+	if b == nil {
+		b = g.everyoneRole()
+	}
+
+	switch {
+	case a.ID == b.ID:
+		return 0
+	case roleAbove(a, b):
+		return 1
+	}
+	return -1
+}
+
+// checkPermission returns a ModerationError if the actor is missing permission.
+func (g *Guild) checkPermission(action string, actor *Member, permission Permissions) error {
+	if !memberPermissions(g, nil, nil, actor).Has(permission) {
+		return &ModerationError{Action: action, Reason: ModerationMissingPermission, Permission: permission}
+	}
+	return nil
+}
+
+// checkMember returns a ModerationError if actor can't act on target.
+func (g *Guild) checkMember(action string, actor, target *Member, permission Permissions) error {
+	if target.User.ID == actor.User.ID {
+		return &ModerationError{Action: action, Reason: ModerationTargetSelf}
+	}
+	if target.User.ID == g.OwnerID {
+		return &ModerationError{Action: action, Reason: ModerationTargetOwner}
+	}
+	if err := g.checkPermission(action, actor, permission); err != nil {
+		return err
+	}
+	if g.CompareMembers(actor, target) <= 0 {
+		return &ModerationError{Action: action, Reason: ModerationTargetHigher}
+	}
+	return nil
+}
+
+// CanKick returns nil if actor can kick target from the guild, or else a
+// *ModerationError with the reason.
+func (g *Guild) CanKick(actor, target *Member) error {
+	return g.checkMember(ModerationKick, actor, target, PermissionKickMembers)
+}
+
+// CanBan returns nil if actor can ban target from the guild, or else a
+// *ModerationError with the reason.
+// target : The member to ban, nil if the user isn't a member of the guild.
+func (g *Guild) CanBan(actor, target *Member) error {
+	if target == nil {
+		return g.checkPermission(ModerationBan, actor, PermissionBanMembers)
+	}
+	return g.checkMember(ModerationBan, actor, target, PermissionBanMembers)
+}
+
+// CanManageRole returns nil if actor can add role to or remove it from
+// members, or else a *ModerationError with the reason.
+func (g *Guild) CanManageRole(actor *Member, role *Role) error {
+	if role.Managed {
+		return &ModerationError{Action: ModerationManageRole, Reason: ModerationRoleManaged}
+	}
+	if role.ID == g.ID {
+		return &ModerationError{Action: ModerationManageRole, Reason: ModerationRoleEveryone}
+	}
+	if err := g.checkPermission(ModerationManageRole, actor, PermissionManageRoles); err != nil {
+		return err
+	}
+	if actor.User.ID != g.OwnerID && g.compareRole(g.HighestRole(actor), role) <= 0 {
+		return &ModerationError{Action: ModerationManageRole, Reason: ModerationTargetHigher}
+	}
+	return nil
+}
+
+// CanEditNickname returns nil if actor can change the nickname of target,
+// which may be the actor themselves, or else a *ModerationError with the reason.
+func (g *Guild) CanEditNickname(actor, target *Member) error {
+	if actor.User.ID == target.User.ID {
+		if actor.User.ID == g.OwnerID {
+			return nil
+		}
+
+		permissions := memberPermissions(g, nil, nil, actor)
+		if !permissions.Has(PermissionChangeNickname) && !permissions.Has(PermissionManageNicknames) {
+			return &ModerationError{Action: ModerationEditNickname, Reason: ModerationMissingPermission, Permission: PermissionChangeNickname}
+		}
+		return nil
+	}
+	return g.checkMember(ModerationEditNickname, actor, target, PermissionManageNicknames)
+}
+
+// HighestRole returns the highest role of a member, or nil if the member
+// has no roles except @everyone.
+// guildID : The ID of the guild.
+// userID  : The ID of the member.
+func (s *State) HighestRole(guildID, userID string) (*Role, error) {
+	guild, member, err := s.guildMember(guildID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return guild.HighestRole(member), nil
+}
+
+// CompareMembers compares two members in the role hierarchy of a guild,
+// see Guild.CompareMembers.
+func (s *State) CompareMembers(guildID, userID1, userID2 string) (int, error) {
+	guild, a, err := s.guildMember(guildID, userID1)
+	if err != nil {
+		return 0, err
+	}
+	b, err := s.Member(guildID, userID2)
+	if err != nil {
+		return 0, err
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return guild.CompareMembers(a, b), nil
+}
+
+// CanKick returns nil if the actor can kick the target, see Guild.CanKick.
+// An error of the State is returned if a member isn't cached.
+func (s *State) CanKick(guildID, actorID, targetID string) error {
+	return s.checkMembers(guildID, actorID, targetID, (*Guild).CanKick)
+}
+
+// CanBan returns nil if the actor can ban the target, see Guild.CanBan.
+// The target doesn't have to be a cached member, the role hierarchy is only
+// checked if they are.
+func (s *State) CanBan(guildID, actorID, targetID string) error {
+	guild, actor, err := s.guildMember(guildID, actorID)
+	if err != nil {
+		return err
+	}
+	target, _ := s.Member(guildID, targetID)
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return guild.CanBan(actor, target)
+}
+
+// CanManageRole returns nil if the actor can add the role to or remove it
+// from members, see Guild.CanManageRole.
+func (s *State) CanManageRole(guildID, actorID, roleID string) error {
+	guild, actor, err := s.guildMember(guildID, actorID)
+	if err != nil {
+		return err
+	}
+	role, err := s.Role(guildID, roleID)
+	if err != nil {
+		return err
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return guild.CanManageRole(actor, role)
+}
+
+// CanEditNickname returns nil if the actor can change the nickname of the
+// target, see Guild.CanEditNickname.
+func (s *State) CanEditNickname(guildID, actorID, targetID string) error {
+	return s.checkMembers(guildID, actorID, targetID, (*Guild).CanEditNickname)
+}
+
+// checkMembers looks up the guild and members and runs a check on them.
+func (s *State) checkMembers(guildID, actorID, targetID string, check func(*Guild, *Member, *Member) error) error {
+	guild, actor, err := s.guildMember(guildID, actorID)
+	if err != nil {
+		return err
+	}
+	target, err := s.Member(guildID, targetID)
+	if err != nil {
+		return err
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return check(guild, actor, target)
+}
+
+// guildMember returns a guild and one of its members from the state.
+func (s *State) guildMember(guildID, userID string) (*Guild, *Member, error) {
+	if s == nil {
+		return nil, nil, ErrNilState
+	}
+
+	guild, err := s.Guild(guildID)
+	if err != nil {
+		return nil, nil, err
+	}
+	member, err := s.Member(guildID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return guild, member, nil
+}
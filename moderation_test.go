@@ -0,0 +1,151 @@
+package discordgo
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func moderationTestGuild() *Guild {
+	return &Guild{
+		ID:      "1",
+		OwnerID: "10",
+		Roles: []*Role{
+			{ID: "1", Position: 0},
+			{ID: "2", Position: 3, Permissions: PermissionAdministrator},
+			{ID: "3", Position: 2, Permissions: PermissionKickMembers | PermissionBanMembers | PermissionManageRoles | PermissionManageNicknames},
+			{ID: "4", Position: 2},
+			{ID: "5", Position: 1, Managed: true},
+			{ID: "6", Position: 1, Permissions: PermissionChangeNickname},
+		},
+		Members: []*Member{
+			{GuildID: "1", User: &User{ID: "10"}},
+			{GuildID: "1", User: &User{ID: "20"}, Roles: []string{"2"}},
+			{GuildID: "1", User: &User{ID: "30"}, Roles: []string{"3"}},
+			{GuildID: "1", User: &User{ID: "40"}, Roles: []string{"4"}},
+			{GuildID: "1", User: &User{ID: "50"}, Roles: []string{"6"}},
+			{GuildID: "1", User: &User{ID: "60"}},
+		},
+	}
+}
+
+func TestGuildHierarchy(t *testing.T) {
+	g := moderationTestGuild()
+	m := func(i int) *Member { return g.Members[i] }
+
+	if r := g.HighestRole(&Member{User: &User{ID: "70"}, Roles: []string{"6", "3", "4"}}); r == nil || r.ID != "3" {
+		t.Errorf("expected role 3 to be the highest, got %+v", r)
+	}
+	if r := g.HighestRole(m(5)); r != nil {
+		t.Errorf("expected no role, got %+v", r)
+	}
+
+	tests := []struct {
+		a, b int
+		want int
+	}{
+		{0, 1, 1},  // owner above everyone
+		{1, 2, 1},  // position 3 above 2
+		{2, 3, 1},  // same position, role 3 is older than role 4
+		{3, 2, -1}, // and the other way around
+		{5, 4, -1}, // @everyone is the lowest
+		{2, 2, 0},
+	}
+	for _, test := range tests {
+		if got := g.CompareMembers(m(test.a), m(test.b)); got != test.want {
+			t.Errorf("CompareMembers(%s, %s) = %d, want %d", m(test.a).User.ID, m(test.b).User.ID, got, test.want)
+		}
+	}
+}
+
+func TestRolesSort(t *testing.T) {
+	roles := Roles{
+		{ID: "81384788765712386", Position: 1},
+		{ID: "81384788765712390", Position: 2},
+		{ID: "81384788765712385", Position: 1},
+		{ID: "9384788765712385", Position: 1},
+	}
+	sort.Sort(roles)
+
+	// Roles with the same position are ordered by their IDs, like Discord does.
+	expected := []string{"81384788765712390", "9384788765712385", "81384788765712385", "81384788765712386"}
+	for i, id := range expected {
+		if roles[i].ID != id {
+			t.Errorf("expected role %s at %d, got %s", id, i, roles[i].ID)
+		}
+	}
+}
+
+func TestGuildModerationChecks(t *testing.T) {
+	g := moderationTestGuild()
+	m := func(i int) *Member { return g.Members[i] }
+	role := func(id string) *Role {
+		for _, r := range g.Roles {
+			if r.ID == id {
+				return r
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{"owner kicks admin", g.CanKick(m(0), m(1)), ""},
+		{"admin kicks owner", g.CanKick(m(1), m(0)), ModerationTargetOwner},
+		{"moderator kicks admin", g.CanKick(m(2), m(1)), ModerationTargetHigher},
+		{"moderator kicks same position", g.CanKick(m(2), m(3)), ""},
+		{"member kicks moderator", g.CanKick(m(3), m(2)), ModerationMissingPermission},
+		{"moderator kicks themselves", g.CanKick(m(2), m(2)), ModerationTargetSelf},
+		{"moderator bans non-member", g.CanBan(m(2), nil), ""},
+		{"member bans non-member", g.CanBan(m(4), nil), ModerationMissingPermission},
+		{"admin manages managed role", g.CanManageRole(m(1), role("5")), ModerationRoleManaged},
+		{"admin manages everyone", g.CanManageRole(m(1), role("1")), ModerationRoleEveryone},
+		{"moderator manages own role", g.CanManageRole(m(2), role("3")), ModerationTargetHigher},
+		{"moderator manages lower role", g.CanManageRole(m(2), role("6")), ""},
+		{"owner manages top role", g.CanManageRole(m(0), role("2")), ""},
+		{"member edits own nickname", g.CanEditNickname(m(4), m(4)), ""},
+		{"no role edits own nickname", g.CanEditNickname(m(5), m(5)), ModerationMissingPermission},
+		{"moderator edits lower nickname", g.CanEditNickname(m(2), m(4)), ""},
+		{"admin edits owner nickname", g.CanEditNickname(m(1), m(0)), ModerationTargetOwner},
+	}
+
+	for _, test := range tests {
+		if test.reason == "" {
+			if test.err != nil {
+				t.Errorf("%s: unexpected error %v", test.name, test.err)
+			}
+			continue
+		}
+
+		var modErr *ModerationError
+		if !errors.As(test.err, &modErr) || modErr.Reason != test.reason || !errors.Is(test.err, ErrModeration) {
+			t.Errorf("%s: expected %q, got %v", test.name, test.reason, test.err)
+		}
+	}
+}
+
+func TestStateModerationChecks(t *testing.T) {
+	state := NewState()
+	if err := state.GuildAdd(moderationTestGuild()); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err := state.HighestRole("1", "30"); err != nil || r.ID != "3" {
+		t.Errorf("expected role 3, got %+v, %v", r, err)
+	}
+	if err := state.CanKick("1", "30", "40"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := state.CanBan("1", "30", "999"); err != nil {
+		t.Errorf("expected to ban users who aren't cached, got %v", err)
+	}
+	if err := state.CanManageRole("1", "30", "2"); !errors.Is(err, ErrModeration) {
+		t.Errorf("expected a moderation error, got %v", err)
+	}
+	if err := state.CanKick("1", "30", "999"); err != ErrStateNotFound {
+		t.Errorf("expected the missing member to be reported, got %v", err)
+	}
+}
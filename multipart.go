@@ -0,0 +1,420 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the request bodies used by the REST API functions,
+// including the multipart bodies which stream files to Discord.
+
+package discordgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// Upload errors
+var (
+	ErrUploadTooLarge    = errors.New("upload exceeds the file size limit")
+	ErrFileNotRewindable = errors.New("file can not be sent again, its Reader has to be an io.Seeker or Open has to be set")
+)
+
+// Upload limits of guilds depending on their premium tier.
+const (
+	UploadLimitDefault int64 = 8 * 1024 * 1024
+	UploadLimitTier2   int64 = 50 * 1024 * 1024
+	UploadLimitTier3   int64 = 100 * 1024 * 1024
+)
+
+// UploadLimit returns the maximum size of all files sent in one message to a
+// guild with the given premium tier.
+func UploadLimit(tier PremiumTier) int64 {
+	switch tier {
+	case PremiumTier2:
+		return UploadLimitTier2
+	case PremiumTier3:
+		return UploadLimitTier3
+	default:
+		return UploadLimitDefault
+	}
+}
+
+// A requestBody opens the body of a REST request. Open is called for every
+// attempt to send the request, so retries don't need a buffered copy.
+type requestBody interface {
+	Open() (io.ReadCloser, error)
+
+	// Len returns the length of the body, if it is known.
+	Len() (int64, bool)
+}
+
+// bytesBody is a request body which is already in memory.
+type bytesBody []byte
+
+func (b bytesBody) Open() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (b bytesBody) Len() (int64, bool) {
+	return int64(len(b)), true
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// multipartBody is a multipart/form-data request body with a JSON payload
+// and files, which are streamed through a pipe while the request is sent.
+type multipartBody struct {
+	payload  []byte
+	files    []*File
+	boundary string
+
+	// sizes of the files, -1 if unknown.
+	sizes []int64
+	// offsets of the files' readers when they were first sent, -1 if they can't seek.
+	offsets []int64
+	opened  bool
+
+	// pr is the pipe of the last attempt, done is closed once its writer returned.
+	pr   *io.PipeReader
+	done chan struct{}
+}
+
+// newMultipartBody returns a multipart body sending payload as payload_json
+// and the files as file0, file1, ...
+func newMultipartBody(payload []byte, files []*File) *multipartBody {
+	m := &multipartBody{
+		payload:  payload,
+		files:    files,
+		boundary: multipart.NewWriter(ioutil.Discard).Boundary(),
+		sizes:    make([]int64, len(files)),
+		offsets:  make([]int64, len(files)),
+	}
+
+	for i, f := range files {
+		m.sizes[i] = f.size()
+	}
+	return m
+}
+
+// ContentType returns the Content-Type header of the body.
+func (m *multipartBody) ContentType() string {
+	return "multipart/form-data; boundary=" + m.boundary
+}
+
+// Len returns the length of the body if the sizes of all files are known.
+func (m *multipartBody) Len() (int64, bool) {
+	var cw countingWriter
+	if err := m.write(&cw, nil); err != nil {
+		return 0, false
+	}
+
+	n := cw.n
+	for _, size := range m.sizes {
+		if size < 0 {
+			return 0, false
+		}
+		n += size
+	}
+	return n, true
+}
+
+// FilesSize returns the combined size of all files whose size is known.
+func (m *multipartBody) FilesSize() (total int64) {
+	for _, size := range m.sizes {
+		if size > 0 {
+			total += size
+		}
+	}
+	return
+}
+
+// Open opens all files and starts writing the body into a pipe.
+// The writer of a previous attempt is stopped first, so it doesn't read from
+// the files anymore when they are rewound.
+func (m *multipartBody) Open() (io.ReadCloser, error) {
+	if m.done != nil {
+		m.pr.Close()
+		<-m.done
+	}
+
+	readers := make([]io.ReadCloser, len(m.files))
+	for i := range m.files {
+		r, err := m.openFile(i)
+		if err != nil {
+			for _, r := range readers[:i] {
+				r.Close()
+			}
+			return nil, err
+		}
+		readers[i] = r
+	}
+	m.opened = true
+
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := m.write(pw, readers)
+		for _, r := range readers {
+			r.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	m.pr, m.done = pr, done
+
+	return pr, nil
+}
+
+// openFile opens a file for sending it, when it was sent before it is rewound or reopened.
+// Readers which can't seek are streamed once, sending them again fails with ErrFileNotRewindable.
+func (m *multipartBody) openFile(i int) (io.ReadCloser, error) {
+	f := m.files[i]
+	if f.Open != nil {
+		return f.Open()
+	}
+
+	seeker, canSeek := f.Reader.(io.Seeker)
+	if !m.opened {
+		m.offsets[i] = -1
+		if canSeek {
+			if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+				m.offsets[i] = offset
+			}
+		}
+		return ioutil.NopCloser(f.Reader), nil
+	}
+
+	if m.offsets[i] < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotRewindable, f.Name)
+	}
+	if _, err := seeker.Seek(m.offsets[i], io.SeekStart); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(f.Reader), nil
+}
+
+// write writes the multipart body to w. If readers is nil, the contents of
+// the files are left out, which is used to measure the size of the body.
+func (m *multipartBody) write(w io.Writer, readers []io.ReadCloser) (err error) {
+	bodywriter := multipart.NewWriter(w)
+	if err = bodywriter.SetBoundary(m.boundary); err != nil {
+		return
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="payload_json"`)
+	h.Set("Content-Type", "application/json")
+
+	p, err := bodywriter.CreatePart(h)
+	if err != nil {
+		return
+	}
+
+	if _, err = p.Write(m.payload); err != nil {
+		return
+	}
+
+	for i, file := range m.files {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file%d"; filename="%s"`, i, quoteEscaper.Replace(file.filename())))
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		h.Set("Content-Type", contentType)
+
+		p, err = bodywriter.CreatePart(h)
+		if err != nil {
+			return
+		}
+
+		if readers != nil {
+			if _, err = io.Copy(p, readers[i]); err != nil {
+				return
+			}
+		}
+	}
+
+	return bodywriter.Close()
+}
+
+// filename returns the name the file is uploaded with.
+func (f *File) filename() string {
+	if f.Spoiler && !strings.HasPrefix(f.Name, "SPOILER_") {
+		return "SPOILER_" + f.Name
+	}
+	return f.Name
+}
+
+// multipartPayload returns data as payload_json for a multipart body.
+// The files are appended to the attachments of data, so their descriptions
+// are sent. Edits replace the attachments of a message with the listed ones,
+// so the files are only listed if data lists the attachments to keep.
+// The attachments of interaction responses are part of their data.
+func multipartPayload(data interface{}, files []*File, edit bool) ([]byte, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.(*InteractionResponse); !ok {
+		return attachFiles(payload, files, edit)
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+	inner := fields["data"]
+	if len(inner) == 0 || string(inner) == "null" {
+		inner = json.RawMessage("{}")
+	}
+	if fields["data"], err = attachFiles(inner, files, edit); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// attachFiles appends the files to the attachments of the JSON object payload.
+func attachFiles(payload []byte, files []*File, edit bool) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+
+	var attachments []json.RawMessage
+	raw, ok := fields["attachments"]
+	if !ok && edit {
+		// Listing only the files would remove the existing attachments.
+		return payload, nil
+	}
+	if ok {
+		if err := json.Unmarshal(raw, &attachments); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, f := range files {
+		raw, err := json.Marshal(struct {
+			ID          int    `json:"id"`
+			Filename    string `json:"filename"`
+			Description string `json:"description,omitempty"`
+		}{i, f.filename(), f.Description})
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, raw)
+	}
+
+	var err error
+	if fields["attachments"], err = json.Marshal(attachments); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// requestWithFiles sends data as JSON, or as a multipart body when there are files.
+// channelID is used to check the upload limit and may be empty if it is unknown.
+func (s *Session) requestWithFiles(method, urlStr, channelID string, data interface{}, files []*File, bucketID string, options ...RequestOption) (response []byte, err error) {
+	if len(files) == 0 {
+		return s.RequestWithBucketID(method, urlStr, data, bucketID, options...)
+	}
+
+	payload, err := multipartPayload(data, files, method == "PATCH")
+	if err != nil {
+		return
+	}
+
+	body := newMultipartBody(payload, files)
+	if channelID != "" {
+		if err = s.checkUploadSize(channelID, body); err != nil {
+			return
+		}
+	}
+
+	return s.requestWithBody(method, urlStr, body.ContentType(), body, bucketID, 0, options...)
+}
+
+// size returns the size of the file, or -1 if it can't be determined.
+func (f *File) size() int64 {
+	if f.Size > 0 {
+		return f.Size
+	}
+	if f.Open != nil || f.Reader == nil {
+		return -1
+	}
+
+	// bytes.Buffer, bytes.Reader and strings.Reader
+	if l, ok := f.Reader.(interface{ Len() int }); ok {
+		return int64(l.Len())
+	}
+
+	if seeker, ok := f.Reader.(io.Seeker); ok {
+		current, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err = seeker.Seek(current, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - current
+	}
+
+	return -1
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// uploadLimit returns the upload limit of a channel, or 0 if it is unknown.
+func (s *Session) uploadLimit(channelID string) int64 {
+	if s.State == nil {
+		return 0
+	}
+
+	c, err := s.State.Channel(channelID)
+	if err != nil {
+		return 0
+	}
+	if c.GuildID == "" {
+		return UploadLimitDefault
+	}
+
+	g, err := s.State.Guild(c.GuildID)
+	if err != nil {
+		return 0
+	}
+	return UploadLimit(g.PremiumTier)
+}
+
+// checkUploadSize returns an error if the files of body exceed the upload limit of the channel.
+func (s *Session) checkUploadSize(channelID string, body *multipartBody) error {
+	limit := s.uploadLimit(channelID)
+	if limit == 0 {
+		return nil
+	}
+
+	if size := body.FilesSize(); size > limit {
+		return fmt.Errorf("%w: %d bytes, the limit of the channel is %d bytes", ErrUploadTooLarge, size, limit)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package discordgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMultipartBodyRetry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Errorf("invalid Content-Type: %v", err)
+		}
+		if r.ContentLength <= 0 {
+			t.Errorf("expected a known Content-Length, got %d", r.ContentLength)
+		}
+
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		if _, err = mr.NextPart(); err != nil {
+			t.Errorf("missing payload_json part: %v", err)
+		}
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("missing file part: %v", err)
+		}
+		content, _ := ioutil.ReadAll(part)
+		if string(content) != "file content" {
+			t.Errorf("unexpected file content %q", content)
+		}
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	policy := NewRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	s := newRetryTestSession(policy)
+
+	body := newMultipartBody([]byte(`{}`), []*File{{Name: "a.txt", Reader: strings.NewReader("file content")}})
+	urlStr := srv.URL + "/api/v8/channels/81384788765712384/messages"
+	if _, err := s.requestWithBody("PUT", urlStr, body.ContentType(), body, urlStr, 0); err != nil {
+		t.Fatalf("request returned error: %v", err)
+	}
+
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestMultipartBodyNotRewindable(t *testing.T) {
+	body := newMultipartBody([]byte(`{}`), []*File{{Name: "a.txt", Reader: bytes.NewBufferString("file content")}})
+
+	r, err := body.Open()
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	ioutil.ReadAll(r)
+
+	if _, err = body.Open(); !errors.Is(err, ErrFileNotRewindable) {
+		t.Errorf("expected ErrFileNotRewindable, got %v", err)
+	}
+}
+
+func TestMultipartBodyAbandonedAttempt(t *testing.T) {
+	content := strings.Repeat("file content ", 10000)
+	body := newMultipartBody([]byte(`{}`), []*File{{Name: "a.txt", Reader: strings.NewReader(content)}})
+
+	// The first attempt is aborted while its writer still reads the file.
+	r, err := body.Open()
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	r.Read(make([]byte, 512))
+
+	r, err = body.Open()
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	sent, _ := ioutil.ReadAll(r)
+	if !bytes.Contains(sent, []byte(content)) {
+		t.Errorf("expected the whole file to be sent again")
+	}
+}
+
+func TestChannelMessageSendComplexUploadLimit(t *testing.T) {
+	s, _ := New("")
+	s.State.GuildAdd(&Guild{ID: "1", PremiumTier: PremiumTier1})
+	s.State.ChannelAdd(&Channel{ID: "2", GuildID: "1"})
+
+	_, err := s.ChannelMessageSendComplex("2", &MessageSend{
+		Files: []*File{{Name: "large.bin", Reader: strings.NewReader("x"), Size: UploadLimitDefault + 1}},
+	})
+	if !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("expected ErrUploadTooLarge, got %v", err)
+	}
+}
+
+func TestMultipartPayloadAttachments(t *testing.T) {
+	content := "edited"
+	edit := &MessageEdit{
+		Content:     &content,
+		Attachments: &[]*MessageAttachment{{ID: "81384788765712384", Filename: "kept.png"}},
+	}
+	files := []*File{{Name: "new.png", Description: "a cat", Spoiler: true}}
+
+	payload, err := multipartPayload(edit, files, true)
+	if err != nil {
+		t.Fatalf("multipartPayload returned error: %v", err)
+	}
+
+	var data struct {
+		Content     string `json:"content"`
+		Attachments []struct {
+			ID          interface{} `json:"id"`
+			Filename    string      `json:"filename"`
+			Description string      `json:"description"`
+		} `json:"attachments"`
+	}
+	if err = json.Unmarshal(payload, &data); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+
+	if data.Content != content {
+		t.Errorf("expected content %q, got %q", content, data.Content)
+	}
+	if len(data.Attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(data.Attachments))
+	}
+	if data.Attachments[0].ID != "81384788765712384" {
+		t.Errorf("expected the kept attachment first, got %v", data.Attachments[0].ID)
+	}
+	if a := data.Attachments[1]; a.Filename != "SPOILER_new.png" || a.Description != "a cat" {
+		t.Errorf("unexpected attachment for the new file: %+v", a)
+	}
+}
+
+func TestMultipartPayloadEditKeepsAttachments(t *testing.T) {
+	content := "edited"
+	payload, err := multipartPayload(&MessageEdit{Content: &content}, []*File{{Name: "new.png"}}, true)
+	if err != nil {
+		t.Fatalf("multipartPayload returned error: %v", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(payload, &fields); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if _, ok := fields["attachments"]; ok {
+		t.Errorf("expected no attachments in an edit which doesn't list them, got %s", fields["attachments"])
+	}
+}
+
+func TestMultipartPayloadInteractionResponse(t *testing.T) {
+	resp := &InteractionResponse{
+		Type: InteractionResponseTypeChannelMessageWithSource,
+		Data: &InteractionApplicationCommandCallbackData{Content: "file"},
+	}
+	payload, err := multipartPayload(resp, []*File{{Name: "a.png", Description: "a cat"}}, false)
+	if err != nil {
+		t.Fatalf("multipartPayload returned error: %v", err)
+	}
+
+	var data struct {
+		Attachments interface{} `json:"attachments"`
+		Data        struct {
+			Content     string `json:"content"`
+			Attachments []struct {
+				Description string `json:"description"`
+			} `json:"attachments"`
+		} `json:"data"`
+	}
+	if err = json.Unmarshal(payload, &data); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+
+	if data.Attachments != nil {
+		t.Errorf("expected no top-level attachments, got %v", data.Attachments)
+	}
+	if data.Data.Content != "file" || len(data.Data.Attachments) != 1 || data.Data.Attachments[0].Description != "a cat" {
+		t.Errorf("unexpected data %+v", data.Data)
+	}
+}
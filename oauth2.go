@@ -31,9 +31,9 @@ type Application struct {
 
 // Application returns an Application structure of a specific Application
 //   appID : The ID of an Application
-func (s *Session) Application(appID string) (st *Application, err error) {
+func (s *Session) Application(appID string, options ...RequestOption) (st *Application, err error) {
 
-	body, err := s.RequestWithBucketID("GET", EndpointOAuthApplication(appID), nil, EndpointOAuthApplication(""))
+	body, err := s.RequestWithBucketID("GET", EndpointOAuthApplication(appID), nil, EndpointOAuthApplication(""), options...)
 	if err != nil {
 		return
 	}
@@ -43,9 +43,9 @@ func (s *Session) Application(appID string) (st *Application, err error) {
 }
 
 // Applications returns all applications for the authenticated user
-func (s *Session) Applications() (st []*Application, err error) {
+func (s *Session) Applications(options ...RequestOption) (st []*Application, err error) {
 
-	body, err := s.RequestWithBucketID("GET", EndpointOAuthApplications, nil, EndpointOAuthApplications)
+	body, err := s.RequestWithBucketID("GET", EndpointOAuthApplications, nil, EndpointOAuthApplications, options...)
 	if err != nil {
 		return
 	}
@@ -57,7 +57,7 @@ func (s *Session) Applications() (st []*Application, err error) {
 // ApplicationCreate creates a new Application
 //    name : Name of Application / Bot
 //    uris : Redirect URIs (Not required)
-func (s *Session) ApplicationCreate(ap *Application) (st *Application, err error) {
+func (s *Session) ApplicationCreate(ap *Application, options ...RequestOption) (st *Application, err error) {
 
 	data := struct {
 		Name         string    `json:"name"`
@@ -65,7 +65,7 @@ func (s *Session) ApplicationCreate(ap *Application) (st *Application, err error
 		RedirectURIs *[]string `json:"redirect_uris,omitempty"`
 	}{ap.Name, ap.Description, ap.RedirectURIs}
 
-	body, err := s.RequestWithBucketID("POST", EndpointOAuthApplications, data, EndpointOAuthApplications)
+	body, err := s.RequestWithBucketID("POST", EndpointOAuthApplications, data, EndpointOAuthApplications, options...)
 	if err != nil {
 		return
 	}
@@ -76,7 +76,7 @@ func (s *Session) ApplicationCreate(ap *Application) (st *Application, err error
 
 // ApplicationUpdate updates an existing Application
 //   var : desc
-func (s *Session) ApplicationUpdate(appID string, ap *Application) (st *Application, err error) {
+func (s *Session) ApplicationUpdate(appID string, ap *Application, options ...RequestOption) (st *Application, err error) {
 
 	data := struct {
 		Name         string    `json:"name"`
@@ -84,7 +84,7 @@ func (s *Session) ApplicationUpdate(appID string, ap *Application) (st *Applicat
 		RedirectURIs *[]string `json:"redirect_uris,omitempty"`
 	}{ap.Name, ap.Description, ap.RedirectURIs}
 
-	body, err := s.RequestWithBucketID("PUT", EndpointOAuthApplication(appID), data, EndpointOAuthApplication(""))
+	body, err := s.RequestWithBucketID("PUT", EndpointOAuthApplication(appID), data, EndpointOAuthApplication(""), options...)
 	if err != nil {
 		return
 	}
@@ -95,9 +95,9 @@ func (s *Session) ApplicationUpdate(appID string, ap *Application) (st *Applicat
 
 // ApplicationDelete deletes an existing Application
 //   appID : The ID of an Application
-func (s *Session) ApplicationDelete(appID string) (err error) {
+func (s *Session) ApplicationDelete(appID string, options ...RequestOption) (err error) {
 
-	_, err = s.RequestWithBucketID("DELETE", EndpointOAuthApplication(appID), nil, EndpointOAuthApplication(""))
+	_, err = s.RequestWithBucketID("DELETE", EndpointOAuthApplication(appID), nil, EndpointOAuthApplication(""), options...)
 	if err != nil {
 		return
 	}
@@ -113,9 +113,9 @@ type Asset struct {
 }
 
 // ApplicationAssets returns an application's assets
-func (s *Session) ApplicationAssets(appID string) (ass []*Asset, err error) {
+func (s *Session) ApplicationAssets(appID string, options ...RequestOption) (ass []*Asset, err error) {
 
-	body, err := s.RequestWithBucketID("GET", EndpointOAuthApplicationAssets(appID), nil, EndpointOAuthApplicationAssets(""))
+	body, err := s.RequestWithBucketID("GET", EndpointOAuthApplicationAssets(appID), nil, EndpointOAuthApplicationAssets(""), options...)
 	if err != nil {
 		return
 	}
@@ -133,9 +133,9 @@ func (s *Session) ApplicationAssets(appID string) (ass []*Asset, err error) {
 //   appID : The ID of an Application
 //
 // NOTE: func name may change, if I can think up something better.
-func (s *Session) ApplicationBotCreate(appID string) (st *User, err error) {
+func (s *Session) ApplicationBotCreate(appID string, options ...RequestOption) (st *User, err error) {
 
-	body, err := s.RequestWithBucketID("POST", EndpointOAuthApplicationsBot(appID), nil, EndpointOAuthApplicationsBot(""))
+	body, err := s.RequestWithBucketID("POST", EndpointOAuthApplicationsBot(appID), nil, EndpointOAuthApplicationsBot(""), options...)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,290 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the Permissions type and the calculation of the
+// permissions of members.
+
+package discordgo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrThreadParent is returned by MemberPermissions for a thread without its parent.
+var ErrThreadParent = errors.New("the permissions of a thread require its parent channel")
+
+// Permissions is a set of permission flags, e.g. the permissions of a Role.
+// It is encoded as a string in JSON, like Discord does.
+type Permissions int64
+
+// Has returns true if all of the given permissions are set.
+func (p Permissions) Has(permissions Permissions) bool {
+	return p&permissions == permissions
+}
+
+// Add returns the permissions with the given permissions set.
+func (p Permissions) Add(permissions Permissions) Permissions {
+	return p | permissions
+}
+
+// Remove returns the permissions with the given permissions unset.
+func (p Permissions) Remove(permissions Permissions) Permissions {
+	return p &^ permissions
+}
+
+// permissionNames are the names of the permission flags, in the order of their bits.
+var permissionNames = []struct {
+	permission Permissions
+	name       string
+}{
+	{PermissionCreateInstantInvite, "CreateInstantInvite"},
+	{PermissionKickMembers, "KickMembers"},
+	{PermissionBanMembers, "BanMembers"},
+	{PermissionAdministrator, "Administrator"},
+	{PermissionManageChannels, "ManageChannels"},
+	{PermissionManageServer, "ManageServer"},
+	{PermissionAddReactions, "AddReactions"},
+	{PermissionViewAuditLogs, "ViewAuditLogs"},
+	{PermissionVoicePrioritySpeaker, "VoicePrioritySpeaker"},
+	{PermissionVoiceStreamVideo, "VoiceStreamVideo"},
+	{PermissionReadMessages, "ReadMessages"},
+	{PermissionSendMessages, "SendMessages"},
+	{PermissionSendTTSMessages, "SendTTSMessages"},
+	{PermissionManageMessages, "ManageMessages"},
+	{PermissionEmbedLinks, "EmbedLinks"},
+	{PermissionAttachFiles, "AttachFiles"},
+	{PermissionReadMessageHistory, "ReadMessageHistory"},
+	{PermissionMentionEveryone, "MentionEveryone"},
+	{PermissionUseExternalEmojis, "UseExternalEmojis"},
+	{PermissionViewGuildInsights, "ViewGuildInsights"},
+	{PermissionVoiceConnect, "VoiceConnect"},
+	{PermissionVoiceSpeak, "VoiceSpeak"},
+	{PermissionVoiceMuteMembers, "VoiceMuteMembers"},
+	{PermissionVoiceDeafenMembers, "VoiceDeafenMembers"},
+	{PermissionVoiceMoveMembers, "VoiceMoveMembers"},
+	{PermissionVoiceUseVAD, "VoiceUseVAD"},
+	{PermissionChangeNickname, "ChangeNickname"},
+	{PermissionManageNicknames, "ManageNicknames"},
+	{PermissionManageRoles, "ManageRoles"},
+	{PermissionManageWebhooks, "ManageWebhooks"},
+	{PermissionManageEmojis, "ManageEmojis"},
+	{PermissionUseApplicationCommands, "UseApplicationCommands"},
+	{PermissionVoiceRequestToSpeak, "VoiceRequestToSpeak"},
+	{PermissionManageEvents, "ManageEvents"},
+	{PermissionManageThreads, "ManageThreads"},
+	{PermissionCreatePublicThreads, "CreatePublicThreads"},
+	{PermissionCreatePrivateThreads, "CreatePrivateThreads"},
+	{PermissionUseExternalStickers, "UseExternalStickers"},
+	{PermissionSendMessagesInThreads, "SendMessagesInThreads"},
+	{PermissionUseEmbeddedActivities, "UseEmbeddedActivities"},
+	{PermissionModerateMembers, "ModerateMembers"},
+	{PermissionViewCreatorMonetizationAnalytics, "ViewCreatorMonetizationAnalytics"},
+	{PermissionUseSoundboard, "UseSoundboard"},
+	{PermissionCreateGuildExpressions, "CreateGuildExpressions"},
+	{PermissionCreateEvents, "CreateEvents"},
+	{PermissionUseExternalSounds, "UseExternalSounds"},
+	{PermissionSendVoiceMessages, "SendVoiceMessages"},
+	{PermissionSendPolls, "SendPolls"},
+	{PermissionUseExternalApps, "UseExternalApps"},
+}
+
+// String returns the names of the set permissions separated by "|",
+// e.g. "ReadMessages|SendMessages". Unknown bits are appended in hex.
+func (p Permissions) String() string {
+	if p == 0 {
+		return "0"
+	}
+
+	var names []string
+	rest := p
+	for _, n := range permissionNames {
+		if p&n.permission != 0 {
+			names = append(names, n.name)
+			rest &^= n.permission
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint64(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// MarshalJSON encodes the permissions as a string.
+func (p Permissions) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.FormatInt(int64(p), 10))), nil
+}
+
+// UnmarshalJSON decodes permissions from a string or a number.
+func (p *Permissions) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*p = 0
+		return nil
+	}
+
+	v, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid permissions %q: %w", data, err)
+	}
+	*p = Permissions(v)
+	return nil
+}
+
+// permissionsTimedOut are the permissions timed out members keep.
+const permissionsTimedOut = PermissionReadMessages | PermissionReadMessageHistory
+
+// permissionsSendMessages are the permissions which require being able to send messages.
+const permissionsSendMessages = PermissionSendTTSMessages |
+	PermissionMentionEveryone |
+	PermissionAttachFiles |
+	PermissionEmbedLinks |
+	PermissionSendVoiceMessages |
+	PermissionSendPolls
+
+// permissionsVoiceConnect are the permissions which require being able to connect to a voice channel.
+const permissionsVoiceConnect = PermissionVoiceSpeak |
+	PermissionVoiceMuteMembers |
+	PermissionVoiceDeafenMembers |
+	PermissionVoiceMoveMembers |
+	PermissionVoiceUseVAD |
+	PermissionVoicePrioritySpeaker |
+	PermissionVoiceStreamVideo |
+	PermissionVoiceRequestToSpeak |
+	PermissionUseEmbeddedActivities |
+	PermissionUseSoundboard |
+	PermissionUseExternalSounds
+
+// MemberPermissions calculates the permissions of a member in a channel.
+// https://discord.com/developers/docs/topics/permissions#permission-hierarchy
+// guild   : The guild of the member, with its roles.
+// channel : The channel, nil for the permissions on the guild.
+// parent  : The parent of channel, required if it is a thread, threads use the overwrites of their parent.
+// member  : The member, with its roles.
+//
+// Owners and administrators have all permissions. Timed out members only keep
+// PermissionReadMessages and PermissionReadMessageHistory. Without
+// PermissionReadMessages in a channel, all other permissions in it are lost,
+// as are the permissions which depend on sending messages or connecting to a
+// voice channel without those.
+// If channel is a thread and parent isn't its parent, ErrThreadParent is returned.
+func MemberPermissions(guild *Guild, channel, parent *Channel, member *Member) (Permissions, error) {
+	if channel != nil && channel.IsThread() && (parent == nil || parent.ID != channel.ParentID) {
+		return 0, ErrThreadParent
+	}
+	return memberPermissions(guild, channel, parent, member), nil
+}
+
+// memberPermissions calculates the permissions of a member, see MemberPermissions.
+func memberPermissions(guild *Guild, channel, parent *Channel, member *Member) (apermissions Permissions) {
+	userID := member.User.ID
+
+	if userID == guild.OwnerID {
+		return PermissionAll
+	}
+
+	for _, role := range guild.Roles {
+		if role.ID == guild.ID {
+			apermissions |= role.Permissions
+			break
+		}
+	}
+
+	for _, role := range guild.Roles {
+		for _, roleID := range member.Roles {
+			if role.ID == roleID {
+				apermissions |= role.Permissions
+				break
+			}
+		}
+	}
+
+	if apermissions.Has(PermissionAdministrator) {
+		return PermissionAll
+	}
+
+	if channel != nil {
+		overwrites := channel.PermissionOverwrites
+		if channel.IsThread() {
+			overwrites = parent.PermissionOverwrites
+		}
+		apermissions = applyOverwrites(apermissions, overwrites, guild.ID, member)
+	}
+
+	if member.TimedOut() {
+		apermissions &= permissionsTimedOut
+	}
+
+	if channel == nil {
+		return
+	}
+
+	if !apermissions.Has(PermissionReadMessages) {
+		return 0
+	}
+
+	if channel.IsThread() {
+		// Sending messages in threads is a permission of its own.
+		apermissions = apermissions.Remove(PermissionSendMessages)
+		if apermissions.Has(PermissionSendMessagesInThreads) {
+			apermissions |= PermissionSendMessages
+		}
+	}
+
+	if !apermissions.Has(PermissionSendMessages) {
+		apermissions &^= permissionsSendMessages
+	}
+
+	if channel.Type == ChannelTypeGuildVoice || channel.Type == ChannelTypeGuildStageVoice {
+		if !apermissions.Has(PermissionVoiceConnect) {
+			apermissions &^= permissionsVoiceConnect
+		}
+	}
+
+	return
+}
+
+// applyOverwrites applies the permission overwrites of a channel for a member.
+func applyOverwrites(apermissions Permissions, overwrites []*PermissionOverwrite, guildID string, member *Member) Permissions {
+	// Apply @everyone overrides from the channel.
+	for _, overwrite := range overwrites {
+		if guildID == overwrite.ID {
+			apermissions &^= overwrite.Deny
+			apermissions |= overwrite.Allow
+			break
+		}
+	}
+
+	var denies, allows Permissions
+
+	// Member overwrites can override role overrides, so do two passes
+	for _, overwrite := range overwrites {
+		for _, roleID := range member.Roles {
+			if overwrite.Type == PermissionOverwriteTypeRole && roleID == overwrite.ID {
+				denies |= overwrite.Deny
+				allows |= overwrite.Allow
+				break
+			}
+		}
+	}
+
+	apermissions &^= denies
+	apermissions |= allows
+
+	for _, overwrite := range overwrites {
+		if overwrite.Type == PermissionOverwriteTypeMember && overwrite.ID == member.User.ID {
+			apermissions &^= overwrite.Deny
+			apermissions |= overwrite.Allow
+			break
+		}
+	}
+
+	return apermissions
+}
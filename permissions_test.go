@@ -0,0 +1,147 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPermissions(t *testing.T) {
+	p := PermissionReadMessages.Add(PermissionSendMessages | PermissionAttachFiles).Remove(PermissionAttachFiles)
+	if !p.Has(PermissionReadMessages|PermissionSendMessages) || p.Has(PermissionAttachFiles) {
+		t.Errorf("unexpected permissions %s", p)
+	}
+	if got := p.String(); got != "ReadMessages|SendMessages" {
+		t.Errorf("unexpected string %q", got)
+	}
+	if got := (PermissionModerateMembers | 1<<60).String(); got != "ModerateMembers|0x1000000000000000" {
+		t.Errorf("unexpected string %q", got)
+	}
+	if PermissionModerateMembers != 1<<40 || PermissionSendPolls != 1<<49 || PermissionVoiceStreamVideo != 1<<9 {
+		t.Errorf("permission constants have the wrong bits")
+	}
+}
+
+func TestPermissionsJSON(t *testing.T) {
+	var role Role
+	if err := json.Unmarshal([]byte(`{"permissions":"1099511627776"}`), &role); err != nil {
+		t.Fatal(err)
+	}
+	if role.Permissions != PermissionModerateMembers {
+		t.Errorf("expected ModerateMembers, got %s", role.Permissions)
+	}
+
+	// Some older payloads contain numbers.
+	var overwrite PermissionOverwrite
+	if err := json.Unmarshal([]byte(`{"allow":1024,"deny":"2048"}`), &overwrite); err != nil {
+		t.Fatal(err)
+	}
+	if overwrite.Allow != PermissionReadMessages || overwrite.Deny != PermissionSendMessages {
+		t.Errorf("unexpected overwrite %+v", overwrite)
+	}
+
+	data, err := json.Marshal(GuildRoleData{Permissions: PermissionModerateMembers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"permissions":"1099511627776"}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
+
+func TestMemberPermissions(t *testing.T) {
+	guild := &Guild{
+		ID:      "1",
+		OwnerID: "100",
+		Roles: []*Role{
+			{ID: "1", Permissions: PermissionReadMessages | PermissionSendMessages | PermissionEmbedLinks | PermissionVoiceConnect | PermissionVoiceSpeak},
+			{ID: "2", Permissions: PermissionKickMembers},
+			{ID: "3", Permissions: PermissionAdministrator},
+		},
+	}
+	text := &Channel{ID: "10", GuildID: "1", Type: ChannelTypeGuildText, PermissionOverwrites: []*PermissionOverwrite{
+		{ID: "2", Type: PermissionOverwriteTypeRole, Deny: PermissionSendMessages},
+		{ID: "200", Type: PermissionOverwriteTypeMember, Allow: PermissionSendMessages},
+	}}
+	hidden := &Channel{ID: "11", GuildID: "1", Type: ChannelTypeGuildText, PermissionOverwrites: []*PermissionOverwrite{
+		{ID: "1", Type: PermissionOverwriteTypeRole, Deny: PermissionReadMessages},
+	}}
+	voice := &Channel{ID: "12", GuildID: "1", Type: ChannelTypeGuildVoice, PermissionOverwrites: []*PermissionOverwrite{
+		{ID: "2", Type: PermissionOverwriteTypeRole, Deny: PermissionVoiceConnect},
+	}}
+	thread := &Channel{ID: "13", GuildID: "1", ParentID: "10", Type: ChannelTypeGuildPublicThread}
+
+	member := func(id string, roles ...string) *Member {
+		return &Member{GuildID: "1", User: &User{ID: id}, Roles: roles}
+	}
+	timedOut := member("400")
+	timedOut.CommunicationDisabledUntil = Timestamp(time.Now().Add(time.Hour).Format(time.RFC3339))
+	expired := member("500")
+	expired.CommunicationDisabledUntil = Timestamp(time.Now().Add(-time.Hour).Format(time.RFC3339))
+
+	everyone := PermissionReadMessages | PermissionSendMessages | PermissionEmbedLinks | PermissionVoiceConnect | PermissionVoiceSpeak
+	tests := []struct {
+		name    string
+		channel *Channel
+		parent  *Channel
+		member  *Member
+		want    Permissions
+	}{
+		{"guild", nil, nil, member("300", "2"), everyone | PermissionKickMembers},
+		{"owner", hidden, nil, member("100"), PermissionAll},
+		{"administrator", hidden, nil, member("300", "3"), PermissionAll},
+		{"role overwrite", text, nil, member("300", "2"), PermissionReadMessages | PermissionVoiceConnect | PermissionVoiceSpeak | PermissionKickMembers},
+		{"member overwrite", text, nil, member("200", "2"), everyone | PermissionKickMembers},
+		{"hidden channel", hidden, nil, member("300", "2"), 0},
+		{"voice without connect", voice, nil, member("300", "2"), PermissionReadMessages | PermissionSendMessages | PermissionEmbedLinks | PermissionKickMembers},
+		{"thread without send in threads", thread, text, member("300"), PermissionReadMessages | PermissionVoiceConnect | PermissionVoiceSpeak},
+		{"thread with inherited deny", thread, text, member("300", "2"), PermissionReadMessages | PermissionVoiceConnect | PermissionVoiceSpeak | PermissionKickMembers},
+		{"timed out", text, nil, timedOut, PermissionReadMessages},
+		{"timeout expired", text, nil, expired, everyone},
+	}
+
+	for _, test := range tests {
+		if got, err := MemberPermissions(guild, test.channel, test.parent, test.member); err != nil || got != test.want {
+			t.Errorf("%s: got %s, %v, want %s", test.name, got, err, test.want)
+		}
+	}
+
+	guild.Roles[0].Permissions |= PermissionSendMessagesInThreads
+	if got, _ := MemberPermissions(guild, thread, text, member("300")); !got.Has(PermissionSendMessages | PermissionEmbedLinks) {
+		t.Errorf("expected to send messages in the thread, got %s", got)
+	}
+	if got, _ := MemberPermissions(guild, thread, text, member("300", "2")); !got.Has(PermissionSendMessagesInThreads | PermissionSendMessages) {
+		t.Errorf("expected SendMessagesInThreads to allow sending, got %s", got)
+	}
+	if _, err := MemberPermissions(guild, thread, nil, member("300")); err != ErrThreadParent {
+		t.Errorf("expected ErrThreadParent without the parent, got %v", err)
+	}
+}
+
+func TestStateUserChannelPermissionsThread(t *testing.T) {
+	state := NewState()
+	if err := state.GuildAdd(&Guild{
+		ID:      "1",
+		Roles:   []*Role{{ID: "1", Permissions: PermissionReadMessages | PermissionSendMessagesInThreads}},
+		Members: []*Member{{GuildID: "1", User: &User{ID: "2"}}},
+		Channels: []*Channel{
+			{ID: "10", GuildID: "1", Type: ChannelTypeGuildText},
+			{ID: "11", GuildID: "1", ParentID: "10", Type: ChannelTypeGuildPublicThread},
+			{ID: "12", GuildID: "1", ParentID: "13", Type: ChannelTypeGuildPublicThread},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := state.UserChannelPermissions("2", "11")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Has(PermissionReadMessages | PermissionSendMessages) {
+		t.Errorf("unexpected permissions %s", p)
+	}
+
+	if _, err = state.UserChannelPermissions("2", "12"); err != ErrStateNotFound {
+		t.Errorf("expected the missing parent to be reported, got %v", err)
+	}
+}
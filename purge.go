@@ -0,0 +1,225 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains helpers to delete many messages at once.
+
+package discordgo
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BulkDeleteMaxAge is the maximum age of messages which can be bulk deleted.
+const BulkDeleteMaxAge = 14 * 24 * time.Hour
+
+// bulkDeleteMargin keeps messages which are almost too old out of bulk deletes,
+// so they don't become too old while the request is sent.
+const bulkDeleteMargin = time.Minute
+
+// PurgeProgress is passed to PurgeOptions.Progress.
+type PurgeProgress struct {
+	Deleted int
+	Failed  int
+
+	// Total is the number of messages to delete, 0 if it isn't known yet.
+	Total int
+}
+
+// PurgeOptions configure ChannelMessagesPurge and ChannelMessagesPurgeFilter.
+type PurgeOptions struct {
+	// Progress, if set, is called after every bulk or individual delete.
+	Progress func(*PurgeProgress)
+}
+
+// PurgeResult lists the messages a purge deleted and the ones it failed to delete.
+type PurgeResult struct {
+	Deleted []string
+	Failed  map[string]error
+}
+
+// PurgeError is returned when some messages of a purge could not be deleted.
+type PurgeError struct {
+	Failed map[string]error
+}
+
+// Error implements the error interface.
+func (e *PurgeError) Error() string {
+	return fmt.Sprintf("failed to delete %d messages", len(e.Failed))
+}
+
+// purger deletes messages of a channel and keeps track of the results.
+type purger struct {
+	s         *Session
+	channelID string
+	opts      *PurgeOptions
+	options   []RequestOption
+	total     int
+	result    *PurgeResult
+}
+
+func (s *Session) newPurger(channelID string, opts *PurgeOptions, options []RequestOption) *purger {
+	if opts == nil {
+		opts = &PurgeOptions{}
+	}
+
+	return &purger{
+		s:         s,
+		channelID: channelID,
+		opts:      opts,
+		options:   options,
+		result:    &PurgeResult{Failed: make(map[string]error)},
+	}
+}
+
+// ChannelMessagesPurge deletes any number of messages from a channel.
+// Messages are bulk deleted in batches of 100, messages older than
+// BulkDeleteMaxAge are deleted one by one with PriorityLow.
+// If some messages could not be deleted, a *PurgeError is returned
+// together with the result.
+// channelID  : The ID of a Channel.
+// messageIDs : The IDs of the messages to delete.
+// opts       : Optional progress reporting, may be nil.
+func (s *Session) ChannelMessagesPurge(channelID string, messageIDs []string, opts *PurgeOptions, options ...RequestOption) (*PurgeResult, error) {
+	p := s.newPurger(channelID, opts, options)
+
+	seen := make(map[string]bool, len(messageIDs))
+	ids := make([]string, 0, len(messageIDs))
+	for _, id := range messageIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	p.total = len(ids)
+
+	if err := p.purge(ids); err != nil {
+		return p.result, err
+	}
+	return p.finish()
+}
+
+// ChannelMessagesPurgeFilter deletes the messages of a channel for which
+// filter returns true, as ChannelMessagesPurge does.
+// channelID : The ID of a Channel.
+// iterOpts  : Which part of the channel history to go through.
+// filter    : Reports whether a message should be deleted, if nil all messages are deleted.
+// opts      : Optional progress reporting, may be nil.
+func (s *Session) ChannelMessagesPurgeFilter(channelID string, iterOpts IteratorOptions, filter func(*Message) bool, opts *PurgeOptions, options ...RequestOption) (*PurgeResult, error) {
+	p := s.newPurger(channelID, opts, options)
+
+	var pending []string
+	it := s.ChannelMessagesIterator(channelID, iterOpts, options...)
+	for it.Next() {
+		if filter != nil && !filter(it.Message()) {
+			continue
+		}
+
+		pending = append(pending, it.Message().ID)
+		if len(pending) == 100 {
+			if err := p.purge(pending); err != nil {
+				return p.result, err
+			}
+			pending = nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return p.result, err
+	}
+
+	if err := p.purge(pending); err != nil {
+		return p.result, err
+	}
+	return p.finish()
+}
+
+// purge deletes the messages, it only returns an error if the context of the requests was cancelled.
+func (p *purger) purge(ids []string) error {
+	cutoff := time.Now().Add(-BulkDeleteMaxAge + bulkDeleteMargin)
+
+	var recent, old []string
+	for _, id := range ids {
+		if snowflake, err := ParseSnowflake(id); err == nil && snowflake.Time().After(cutoff) {
+			recent = append(recent, id)
+		} else {
+			old = append(old, id)
+		}
+	}
+
+	for len(recent) > 0 {
+		if err := p.cancelled(); err != nil {
+			return err
+		}
+
+		n := len(recent)
+		if n > 100 {
+			n = 100
+		}
+		batch := recent[:n]
+		recent = recent[n:]
+
+		// Bulk deletes need at least 2 messages.
+		if len(batch) == 1 {
+			old = append(old, batch...)
+			continue
+		}
+
+		if err := p.s.ChannelMessagesBulkDelete(p.channelID, batch, p.options...); err != nil {
+			// Find out which messages failed by deleting them one by one.
+			p.s.log(LogInformational, "bulk delete in channel %s failed, deleting messages individually: %s", p.channelID, err)
+			old = append(old, batch...)
+			continue
+		}
+
+		p.result.Deleted = append(p.result.Deleted, batch...)
+		p.progress()
+	}
+
+	sort.Slice(old, func(i, j int) bool { return SnowflakeLess(old[j], old[i]) })
+
+	options := append([]RequestOption{WithPriority(PriorityLow)}, p.options...)
+	for _, id := range old {
+		if err := p.cancelled(); err != nil {
+			return err
+		}
+
+		if err := p.s.ChannelMessageDelete(p.channelID, id, options...); err != nil {
+			p.result.Failed[id] = err
+		} else {
+			p.result.Deleted = append(p.result.Deleted, id)
+		}
+		p.progress()
+	}
+
+	return nil
+}
+
+// cancelled returns the error of the requests' context, if it is done.
+func (p *purger) cancelled() error {
+	if ctx := newRequestConfig("", p.options).Context; ctx != nil {
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (p *purger) progress() {
+	if p.opts.Progress != nil {
+		p.opts.Progress(&PurgeProgress{
+			Deleted: len(p.result.Deleted),
+			Failed:  len(p.result.Failed),
+			Total:   p.total,
+		})
+	}
+}
+
+func (p *purger) finish() (*PurgeResult, error) {
+	if len(p.result.Failed) > 0 {
+		return p.result, &PurgeError{Failed: p.result.Failed}
+	}
+	return p.result, nil
+}
@@ -0,0 +1,110 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestChannelMessagesPurge(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		batches    [][]string
+		individual []string
+	)
+
+	s, closeServer := newIteratorTestSession(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/messages/bulk-delete"):
+			var body struct {
+				Messages []string `json:"messages"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			batches = append(batches, body.Messages)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "DELETE":
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			if id == "failing" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code": 10008, "message": "Unknown Message"}`))
+				return
+			}
+			individual = append(individual, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+	defer closeServer()
+
+	now := time.Now()
+	var ids []string
+	for i := 0; i < 150; i++ {
+		ids = append(ids, SnowflakeFromTime(now.Add(-time.Duration(i)*time.Second)).String())
+	}
+	ids = append(ids, ids[0])
+	for i := 0; i < 3; i++ {
+		ids = append(ids, SnowflakeFromTime(now.Add(-20*24*time.Hour-time.Duration(i)*time.Second)).String())
+	}
+	ids = append(ids, "failing")
+
+	var progress []PurgeProgress
+	result, err := s.ChannelMessagesPurge("1", ids, &PurgeOptions{
+		Progress: func(p *PurgeProgress) { progress = append(progress, *p) },
+	})
+
+	var purgeErr *PurgeError
+	if !errors.As(err, &purgeErr) {
+		t.Fatalf("expected a PurgeError, got %v", err)
+	}
+	if len(purgeErr.Failed) != 1 || purgeErr.Failed["failing"] == nil {
+		t.Errorf("expected only the failing message to fail, got %v", purgeErr.Failed)
+	}
+
+	if len(batches) != 2 || len(batches[0]) != 100 || len(batches[1]) != 50 {
+		t.Errorf("expected bulk deletes of 100 and 50 messages, got %d batches", len(batches))
+	}
+	if len(individual) != 3 {
+		t.Errorf("expected 3 individual deletes, got %d", len(individual))
+	}
+	if len(result.Deleted) != 153 {
+		t.Errorf("expected 153 deleted messages, got %d", len(result.Deleted))
+	}
+
+	last := progress[len(progress)-1]
+	if last.Deleted != 153 || last.Failed != 1 || last.Total != 154 {
+		t.Errorf("unexpected final progress %+v", last)
+	}
+}
+
+func TestChannelMessagesPurgeBulkFailure(t *testing.T) {
+	var individual int
+	s, closeServer := newIteratorTestSession(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": 50034, "message": "You can only bulk delete messages that are under 14 days old."}`))
+			return
+		}
+		individual++
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer closeServer()
+
+	now := time.Now()
+	ids := []string{SnowflakeFromTime(now).String(), SnowflakeFromTime(now.Add(-time.Second)).String()}
+
+	result, err := s.ChannelMessagesPurge("1", ids, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if individual != 2 || len(result.Deleted) != 2 {
+		t.Errorf("expected a fallback to 2 individual deletes, got %d", individual)
+	}
+}
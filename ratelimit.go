@@ -1,6 +1,7 @@
 package discordgo
 
 import (
+	"container/heap"
 	"math"
 	"net/http"
 	"strconv"
@@ -14,6 +15,10 @@ var (
 	GlobalRateLimit      = 48
 	GlobalRateLimitMutex = sync.RWMutex{}
 	GlobalLimit          = false
+
+	// GlobalLimitReserve is the part of GlobalRateLimit which low priority
+	// requests leave to requests of a higher priority.
+	GlobalLimitReserve = 8
 )
 
 // Start a program wide rate limit
@@ -29,6 +34,32 @@ func StartGlobalLimit() {
 	}()
 }
 
+// takeGlobalLimit waits until the program wide rate limit allows a request
+// with the given priority and takes one request from it.
+func takeGlobalLimit(priority RequestPriority) {
+	reserve := 0
+	if priority < PriorityNormal {
+		reserve = GlobalLimitReserve
+	}
+
+	for {
+		GlobalRateLimitMutex.Lock()
+		if GlobalRateLimit > reserve {
+			GlobalRateLimit--
+			GlobalRateLimitMutex.Unlock()
+			return
+		}
+		GlobalRateLimitMutex.Unlock()
+
+		// Higher priorities poll more often, so they get the refreshed limit first.
+		if priority > PriorityNormal {
+			time.Sleep(time.Millisecond / 4)
+		} else {
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
 // customRateLimit holds information for defining a custom rate limit
 type customRateLimit struct {
 	suffix   string
@@ -112,19 +143,33 @@ func (r *RateLimiter) LockBucket(bucketID string) *Bucket {
 
 // LockBucketObject Locks an already resolved bucket until a request can be made
 func (r *RateLimiter) LockBucketObject(b *Bucket) *Bucket {
-	b.Lock()
+	b, _ = r.lockBucketObject(b, PriorityNormal)
+	return b
+}
+
+// lockBucketObject locks the bucket with the given priority and returns how
+// long it had to wait for the rate limit.
+func (r *RateLimiter) lockBucketObject(b *Bucket, priority RequestPriority) (*Bucket, time.Duration) {
+	b.lock(priority)
 
-	if wait := r.GetWaitTime(b, 1); wait > 0 {
+	wait := r.GetWaitTime(b, 1)
+	if wait > 0 {
 		time.Sleep(wait)
 	}
 
 	b.Remaining--
-	return b
+	return b, wait
 }
 
 // Bucket represents a ratelimit bucket, each bucket gets ratelimited individually (-global ratelimits)
 type Bucket struct {
-	sync.Mutex
+	// Waiters for the bucket are ordered by priority instead of
+	// the order in which they called Lock.
+	mu      sync.Mutex
+	locked  bool
+	waiters bucketWaiters
+	nextSeq uint64
+
 	Key       string
 	Remaining int
 	limit     int
@@ -136,6 +181,92 @@ type Bucket struct {
 	Userdata        interface{}
 }
 
+// Lock locks the bucket with PriorityNormal.
+func (b *Bucket) Lock() {
+	b.lock(PriorityNormal)
+}
+
+// lock locks the bucket. If it is already locked, it waits until all waiters
+// of a higher priority, and those of the same priority which came first, had their turn.
+func (b *Bucket) lock(priority RequestPriority) {
+	b.mu.Lock()
+	if !b.locked {
+		b.locked = true
+		b.mu.Unlock()
+		return
+	}
+
+	w := &bucketWaiter{
+		priority: priority,
+		seq:      b.nextSeq,
+		ready:    make(chan struct{}),
+	}
+	b.nextSeq++
+	heap.Push(&b.waiters, w)
+	b.mu.Unlock()
+
+	// The lock is handed over directly by Unlock.
+	<-w.ready
+}
+
+// Unlock unlocks the bucket and hands it over to the waiter with the highest priority.
+func (b *Bucket) Unlock() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.locked {
+		panic("discordgo: unlock of unlocked bucket")
+	}
+
+	if b.waiters.Len() == 0 {
+		b.locked = false
+		return
+	}
+
+	w := heap.Pop(&b.waiters).(*bucketWaiter)
+	close(w.ready)
+}
+
+// waiting returns the number of goroutines waiting to lock the bucket.
+func (b *Bucket) waiting() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.waiters.Len()
+}
+
+// bucketWaiter is a goroutine waiting to lock a Bucket.
+type bucketWaiter struct {
+	priority RequestPriority
+	seq      uint64
+	ready    chan struct{}
+}
+
+// bucketWaiters implements heap.Interface, the highest priority comes first.
+type bucketWaiters []*bucketWaiter
+
+func (w bucketWaiters) Len() int { return len(w) }
+
+func (w bucketWaiters) Less(i, j int) bool {
+	if w[i].priority != w[j].priority {
+		return w[i].priority > w[j].priority
+	}
+	return w[i].seq < w[j].seq
+}
+
+func (w bucketWaiters) Swap(i, j int) { w[i], w[j] = w[j], w[i] }
+
+func (w *bucketWaiters) Push(x interface{}) { *w = append(*w, x.(*bucketWaiter)) }
+
+func (w *bucketWaiters) Pop() interface{} {
+	old := *w
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*w = old[:n-1]
+	return x
+}
+
 // Release unlocks the bucket and reads the headers to update the buckets ratelimit info
 // and locks up the whole thing in case if there's a global ratelimit.
 func (b *Bucket) Release(headers http.Header) error {
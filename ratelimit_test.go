@@ -97,6 +97,35 @@ func TestRatelimitGlobal(t *testing.T) {
 	}
 }
 
+// TestRatelimitPriority tests that waiters of a higher priority get the bucket first
+func TestRatelimitPriority(t *testing.T) {
+	rl := NewRatelimiter()
+	bucket := rl.LockBucket("/guilds/99/priority")
+
+	order := make(chan RequestPriority, 3)
+	for _, p := range []RequestPriority{PriorityLow, PriorityNormal, PriorityHigh} {
+		waiting := bucket.waiting()
+		go func(p RequestPriority) {
+			b, _ := rl.lockBucketObject(bucket, p)
+			order <- p
+			b.Release(nil)
+		}(p)
+
+		// Make sure the goroutines queue up in order.
+		for bucket.waiting() <= waiting {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	bucket.Release(nil)
+
+	for _, expected := range []RequestPriority{PriorityHigh, PriorityNormal, PriorityLow} {
+		if p := <-order; p != expected {
+			t.Errorf("expected priority %d to get the bucket, got %d", expected, p)
+		}
+	}
+}
+
 func BenchmarkRatelimitSingleEndpoint(b *testing.B) {
 	rl := NewRatelimiter()
 	for i := 0; i < b.N; i++ {
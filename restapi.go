@@ -12,6 +12,7 @@ package discordgo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,14 +22,10 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
-	"mime/multipart"
 	"net/http"
-	"net/textproto"
 	"net/url"
-	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -43,69 +40,95 @@ var (
 	ErrUnauthorized            = errors.New("HTTP request was unauthorized. This could be because the provided token was not a bot token. Please add \"Bot \" to the start of your token. https://discordapp.com/developers/docs/reference#authentication-example-bot-token-authorization-header")
 )
 
-var (
-	TotalRequestsSent        = make(map[string]int)
-	TotalRequestsSentMutex   = sync.Mutex{}
-	RequestsPerEndpoint      = make(map[string]map[string]int)
-	RequestsPerEndpointMutex = sync.Mutex{}
-	newIdRegex, _            = regexp.Compile(`(/[a-z2-]+|/@me)+(/|)`)
-	keywords              = []string{"applications", "audit-logs", "bans", "bot", "bulk-delete", "channels", "commands", "connections", "emojis", "gateway", "guilds", "integrations", "invites", "@me", "members", "messages", "nick", "oauth2", "permissions", "pins", "preview", "prune", "reactions", "regions", "roles", "search", "templates", "typing", "users", "voice", "webhooks", "widget", "widget.json"}
+// RequestPriority is the priority of a REST request. When requests wait for
+// the same rate limit bucket or the global rate limit, requests with a higher
+// priority are sent first.
+type RequestPriority int
+
+// Request priorities
+const (
+	// PriorityLow is meant for background work like bulk jobs.
+	// Low priority requests leave part of the global rate limit unused.
+	PriorityLow RequestPriority = -1
+	// PriorityNormal is the default priority.
+	PriorityNormal RequestPriority = 0
+	// PriorityHigh is meant for time critical requests like interaction responses.
+	PriorityHigh RequestPriority = 1
 )
 
-func incrementRequestsSent(token string) {
-	TotalRequestsSentMutex.Lock()
-	defer TotalRequestsSentMutex.Unlock()
+// RequestConfig holds the configuration of a single REST request.
+type RequestConfig struct {
+	Priority RequestPriority
 
-	total, ok := TotalRequestsSent[token]
-	if !ok {
-		TotalRequestsSent[token] = 1
-		return
-	}
+	// Additional headers which are sent with the request.
+	Header http.Header
 
-	TotalRequestsSent[token] = total + 1
+	// Context of the request, cancelling it aborts the request.
+	Context context.Context
 }
 
-func incrementRequestOnEndpoint(url string, method string) {
-	RequestsPerEndpointMutex.Lock()
-	defer RequestsPerEndpointMutex.Unlock()
-	url = url[len(EndpointAPI)-1:]
-	parts := strings.Split(url, "/")
-	for i, part := range parts {
-		if i == 0 && part == "" {
-			continue
-		}
-		f := false
-		for _, keyword := range keywords {
-			if part == keyword {
-				f = true
-				break
-			}
-		}
-		if !f {
-			parts[i] = ":var:"
+// RequestOption is a function which configures a single REST request.
+type RequestOption func(cfg *RequestConfig)
+
+// WithPriority sets the priority of the request.
+func WithPriority(priority RequestPriority) RequestOption {
+	return func(cfg *RequestConfig) {
+		cfg.Priority = priority
+	}
+}
+
+// WithHeader sets a header of the request.
+func WithHeader(key, value string) RequestOption {
+	return func(cfg *RequestConfig) {
+		if cfg.Header == nil {
+			cfg.Header = http.Header{}
 		}
+		cfg.Header.Set(key, value)
 	}
-	url = strings.Join(parts, "/")
-	_, ok := RequestsPerEndpoint[url]
-	if !ok {
-		RequestsPerEndpoint[url] = make(map[string]int)
-		RequestsPerEndpoint[url][method] = 0
-	} else {
-		_, o := RequestsPerEndpoint[url][method]
-		if !o {
-			RequestsPerEndpoint[url][method] = 0
+}
+
+// WithContext sets the context of the request.
+func WithContext(ctx context.Context) RequestOption {
+	return func(cfg *RequestConfig) {
+		cfg.Context = ctx
+	}
+}
+
+// WithAuditLogReason sets the reason shown in the guild's audit log for the
+// changes made by the request. An empty reason is ignored.
+func WithAuditLogReason(reason string) RequestOption {
+	return func(cfg *RequestConfig) {
+		if reason != "" {
+			// Discord decodes the header as a URI component, which doesn't turn + into a space.
+			escaped := strings.ReplaceAll(url.QueryEscape(reason), "+", "%20")
+			WithHeader("X-Audit-Log-Reason", escaped)(cfg)
 		}
 	}
-	RequestsPerEndpoint[url][method] += 1
+}
+
+// newRequestConfig returns the config of a request to urlStr with all options applied.
+// Interaction callbacks and gateway lookups default to PriorityHigh.
+func newRequestConfig(urlStr string, options []RequestOption) *RequestConfig {
+	cfg := &RequestConfig{
+		Priority: PriorityNormal,
+	}
+	if strings.HasPrefix(urlStr, EndpointInteraction) || strings.HasPrefix(urlStr, EndpointGateway) {
+		cfg.Priority = PriorityHigh
+	}
+
+	for _, option := range options {
+		option(cfg)
+	}
+	return cfg
 }
 
 // Request is the same as RequestWithBucketID but the bucket id is the same as the urlStr
-func (s *Session) Request(method, urlStr string, data interface{}) (response []byte, err error) {
-	return s.RequestWithBucketID(method, urlStr, data, strings.SplitN(urlStr, "?", 2)[0])
+func (s *Session) Request(method, urlStr string, data interface{}, options ...RequestOption) (response []byte, err error) {
+	return s.RequestWithBucketID(method, urlStr, data, strings.SplitN(urlStr, "?", 2)[0], options...)
 }
 
 // RequestWithBucketID makes a (GET/POST/...) Requests to Discord REST API with JSON data.
-func (s *Session) RequestWithBucketID(method, urlStr string, data interface{}, bucketID string) (response []byte, err error) {
+func (s *Session) RequestWithBucketID(method, urlStr string, data interface{}, bucketID string, options ...RequestOption) (response []byte, err error) {
 	var body []byte
 	if data != nil {
 		body, err = json.Marshal(data)
@@ -114,50 +137,83 @@ func (s *Session) RequestWithBucketID(method, urlStr string, data interface{}, b
 		}
 	}
 
-	return s.request(method, urlStr, "application/json", body, bucketID, 0)
+	return s.request(method, urlStr, "application/json", body, bucketID, 0, options...)
 }
 
 // request makes a (GET/POST/...) Requests to Discord REST API.
-// Sequence is the sequence number, if it fails with a 502 it will
-// retry with sequence+1 until it either succeeds or sequence >= session.MaxRestRetries
-func (s *Session) request(method, urlStr, contentType string, b []byte, bucketID string, sequence int) (response []byte, err error) {
-	go incrementRequestsSent(s.Token)
-	go incrementRequestOnEndpoint(bucketID, strings.ToUpper(method))
+// Sequence is the sequence number, if it fails in a way the session's RetryPolicy
+// allows it will retry with sequence+1 until it either succeeds or sequence >= session.MaxRestRetries
+func (s *Session) request(method, urlStr, contentType string, b []byte, bucketID string, sequence int, options ...RequestOption) (response []byte, err error) {
+	var body requestBody
+	if b != nil {
+		body = bytesBody(b)
+	}
+	return s.requestWithBody(method, urlStr, contentType, body, bucketID, sequence, options...)
+}
+
+// requestWithBody is the same as request, but the body is opened for every attempt instead of being in memory.
+func (s *Session) requestWithBody(method, urlStr, contentType string, body requestBody, bucketID string, sequence int, options ...RequestOption) (response []byte, err error) {
+	if err = s.checkInvalidRequests(); err != nil {
+		return
+	}
+
+	cfg := newRequestConfig(urlStr, options)
 	if GlobalLimit {
-		if GlobalRateLimit == 0 {
-			log.Println("Global Rate Limit depleted! (" + s.Token + ")") // Note: Temp
-		}
-		for {
-			GlobalRateLimitMutex.RLock()
-			if GlobalRateLimit != 0 {
-				GlobalRateLimitMutex.RUnlock()
-				break
-			}
-			GlobalRateLimitMutex.RUnlock()
-			time.Sleep(time.Millisecond) // Wait for refresh
-		}
-		GlobalRateLimitMutex.Lock()
-		GlobalRateLimit -= 1
-		GlobalRateLimitMutex.Unlock()
+		takeGlobalLimit(cfg.Priority)
 	}
 	if bucketID == "" {
 		bucketID = strings.SplitN(urlStr, "?", 2)[0]
 	}
-	return s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucket(bucketID), sequence)
+	return s.requestWithLockedBucket(method, urlStr, contentType, body, s.lockBucket(method, urlStr, s.Ratelimiter.GetBucket(bucketID), sequence, cfg.Priority), sequence, options...)
 }
 
 // RequestWithLockedBucket makes a request using a bucket that's already been locked
-func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b []byte, bucket *Bucket, sequence int) (response []byte, err error) {
+func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b []byte, bucket *Bucket, sequence int, options ...RequestOption) (response []byte, err error) {
+	var body requestBody
+	if b != nil {
+		body = bytesBody(b)
+	}
+	return s.requestWithLockedBucket(method, urlStr, contentType, body, bucket, sequence, options...)
+}
+
+// requestWithLockedBucket is the same as RequestWithLockedBucket, but the body is opened for every attempt.
+func (s *Session) requestWithLockedBucket(method, urlStr, contentType string, body requestBody, bucket *Bucket, sequence int, options ...RequestOption) (response []byte, err error) {
+	cfg := newRequestConfig(urlStr, options)
+
 	if s.Debug {
 		log.Printf("API REQUEST %8s :: %s\n", method, urlStr)
-		log.Printf("API REQUEST  PAYLOAD :: [%s]\n", string(b))
+		if b, ok := body.(bytesBody); ok {
+			log.Printf("API REQUEST  PAYLOAD :: [%s]\n", string(b))
+		} else if body != nil {
+			log.Printf("API REQUEST  PAYLOAD :: [streamed]\n")
+		}
 	}
 
-	req, err := http.NewRequest(method, urlStr, bytes.NewBuffer(b))
+	req, err := http.NewRequest(method, urlStr, nil)
 	if err != nil {
 		bucket.Release(nil)
 		return
 	}
+	if cfg.Context != nil {
+		req = req.WithContext(cfg.Context)
+	}
+
+	if body != nil {
+		req.Body, err = body.Open()
+		if err != nil {
+			bucket.Release(nil)
+			return
+		}
+
+		if n, ok := body.Len(); ok {
+			req.ContentLength = n
+		} else {
+			req.ContentLength = -1
+		}
+		if b, ok := body.(bytesBody); ok {
+			req.GetBody = b.Open
+		}
+	}
 
 	// Not used on initial login..
 	// TODO: Verify if a login, otherwise complain about no-token
@@ -167,22 +223,36 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 
 	// Discord's API returns a 400 Bad Request is Content-Type is set, but the
 	// request body is empty.
-	if b != nil {
+	if body != nil {
 		req.Header.Set("Content-Type", contentType)
 	}
 
 	// TODO: Make a configurable static variable.
 	req.Header.Set("User-Agent", s.UserAgent)
 
+	for k, v := range cfg.Header {
+		req.Header[k] = v
+	}
+
 	if s.Debug {
 		for k, v := range req.Header {
 			log.Printf("API REQUEST   HEADER :: [%s] = %+v\n", k, v)
 		}
 	}
 
+	info := newRequestInfo(method, urlStr, bucket, sequence)
+	s.hookBeforeRequest(&info)
+	start := time.Now()
+
 	resp, err := s.Client.Do(req)
 	if err != nil {
 		bucket.Release(nil)
+		s.hookAfterResponse(&ResponseInfo{RequestInfo: info, Latency: time.Since(start), Err: err})
+
+		if sequence < s.MaxRestRetries && s.retryPolicy().ShouldRetry(method, 0, err) {
+			s.waitRetry(method, urlStr, 0, err, sequence)
+			return s.requestWithLockedBucket(method, urlStr, contentType, body, s.lockBucket(method, urlStr, bucket, sequence+1, cfg.Priority), sequence+1, options...)
+		}
 		return
 	}
 	defer func() {
@@ -192,12 +262,16 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 		}
 	}()
 
+	s.recordInvalidRequest(urlStr, resp)
+
 	err = bucket.Release(resp.Header)
 	if err != nil {
+		s.hookAfterResponse(&ResponseInfo{RequestInfo: info, StatusCode: resp.StatusCode, Latency: time.Since(start), Err: err})
 		return
 	}
 
 	response, err = ioutil.ReadAll(resp.Body)
+	s.hookAfterResponse(&ResponseInfo{RequestInfo: info, StatusCode: resp.StatusCode, Latency: time.Since(start), Err: err})
 	if err != nil {
 		return
 	}
@@ -215,15 +289,6 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 	case http.StatusOK:
 	case http.StatusCreated:
 	case http.StatusNoContent:
-	case http.StatusBadGateway:
-		// Retry sending request if possible
-		if sequence < s.MaxRestRetries {
-
-			s.log(LogInformational, "%s Failed (%s), Retrying...", urlStr, resp.Status)
-			response, err = s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucketObject(bucket), sequence+1)
-		} else {
-			err = fmt.Errorf("Exceeded Max retries HTTP %s, %s", resp.Status, response)
-		}
 	case 429: // TOO MANY REQUESTS - Rate limiting
 		rl := TooManyRequests{}
 		err = json.Unmarshal(response, &rl)
@@ -232,13 +297,17 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 			return
 		}
 		s.log(LogInformational, "Rate Limiting %s, retry in %f", urlStr, rl.RetryAfter)
-		s.handleEvent(rateLimitEventType, RateLimit{TooManyRequests: &rl, URL: urlStr})
+		s.handleEvent(rateLimitEventType, &RateLimit{TooManyRequests: &rl, URL: urlStr})
 
-		time.Sleep(time.Duration(rl.RetryAfter) * time.Second)
-		// we can make the above smarter
-		// this method can cause longer delays than required
+		// RetryAfter is given in seconds with a fractional part.
+		wait := time.Duration(rl.RetryAfter * float64(time.Second))
+		time.Sleep(wait)
+		s.hookRateLimitWait(&RateLimitWaitInfo{RequestInfo: info, Wait: wait})
 
-		response, err = s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucketObject(bucket), sequence)
+		if err = s.checkInvalidRequests(); err != nil {
+			return
+		}
+		response, err = s.requestWithLockedBucket(method, urlStr, contentType, body, s.lockBucket(method, urlStr, bucket, sequence, cfg.Priority), sequence, options...)
 	case http.StatusUnauthorized:
 		if strings.Index(s.Token, "Bot ") != 0 {
 			s.log(LogInformational, ErrUnauthorized.Error())
@@ -246,7 +315,18 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 		}
 		fallthrough
 	default: // Error condition
-		err = newRestError(req, resp, response)
+		if !s.retryPolicy().ShouldRetry(method, resp.StatusCode, nil) {
+			err = newRestError(req, resp, response)
+			break
+		}
+
+		// Retry sending request if possible
+		if sequence < s.MaxRestRetries {
+			s.waitRetry(method, urlStr, resp.StatusCode, nil, sequence)
+			response, err = s.requestWithLockedBucket(method, urlStr, contentType, body, s.lockBucket(method, urlStr, bucket, sequence+1, cfg.Priority), sequence+1, options...)
+		} else {
+			err = newRestError(req, resp, response)
+		}
 	}
 
 	return
@@ -272,13 +352,13 @@ func unmarshal(data []byte, v interface{}) error {
 // and then use that authentication token for all future connections.
 // Also, doing any form of automation with a user (non Bot) account may result
 // in that account being permanently banned from Discord.
-func (s *Session) Login(email, password string) (err error) {
+func (s *Session) Login(email, password string, options ...RequestOption) (err error) {
 	data := struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
 	}{email, password}
 
-	response, err := s.RequestWithBucketID("POST", EndpointLogin, data, EndpointLogin)
+	response, err := s.RequestWithBucketID("POST", EndpointLogin, data, EndpointLogin, options...)
 	if err != nil {
 		return
 	}
@@ -301,12 +381,12 @@ func (s *Session) Login(email, password string) (err error) {
 // Register sends a Register request to Discord, and returns the authentication token
 // Note that this account is temporary and should be verified for future use.
 // Another option is to save the authentication token external, but this isn't recommended.
-func (s *Session) Register(username string) (token string, err error) {
+func (s *Session) Register(username string, options ...RequestOption) (token string, err error) {
 	data := struct {
 		Username string `json:"username"`
 	}{username}
 
-	response, err := s.RequestWithBucketID("POST", EndpointRegister, data, EndpointRegister)
+	response, err := s.RequestWithBucketID("POST", EndpointRegister, data, EndpointRegister, options...)
 	if err != nil {
 		return
 	}
@@ -328,7 +408,7 @@ func (s *Session) Register(username string) (token string, err error) {
 // This does not seem to actually invalidate the token.  So you can still
 // make API calls even after a Logout.  So, it seems almost pointless to
 // even use.
-func (s *Session) Logout() (err error) {
+func (s *Session) Logout(options ...RequestOption) (err error) {
 	//  _, err = s.Request("POST", LOGOUT, `{"token": "` + s.Token + `"}`)
 	if s.Token == "" {
 		return
@@ -338,7 +418,7 @@ func (s *Session) Logout() (err error) {
 		Token string `json:"token"`
 	}{s.Token}
 
-	_, err = s.RequestWithBucketID("POST", EndpointLogout, data, EndpointLogout)
+	_, err = s.RequestWithBucketID("POST", EndpointLogout, data, EndpointLogout, options...)
 	return
 }
 
@@ -348,8 +428,8 @@ func (s *Session) Logout() (err error) {
 
 // User returns the user details of the given userID
 // userID    : A user ID or "@me" which is a shortcut of current user ID
-func (s *Session) User(userID string) (st *User, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointUser(userID), nil, EndpointUsers)
+func (s *Session) User(userID string, options ...RequestOption) (st *User, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointUser(userID), nil, EndpointUsers, options...)
 	if err != nil {
 		return
 	}
@@ -360,19 +440,19 @@ func (s *Session) User(userID string) (st *User, err error) {
 
 // UserAvatar is deprecated. Please use UserAvatarDecode
 // userID    : A user ID or "@me" which is a shortcut of current user ID
-func (s *Session) UserAvatar(userID string) (img image.Image, err error) {
-	u, err := s.User(userID)
+func (s *Session) UserAvatar(userID string, options ...RequestOption) (img image.Image, err error) {
+	u, err := s.User(userID, options...)
 	if err != nil {
 		return
 	}
-	img, err = s.UserAvatarDecode(u)
+	img, err = s.UserAvatarDecode(u, options...)
 	return
 }
 
 // UserAvatarDecode returns an image.Image of a user's Avatar
 // user : The user which avatar should be retrieved
-func (s *Session) UserAvatarDecode(u *User) (img image.Image, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointUserAvatar(u.ID, u.Avatar), nil, EndpointUserAvatar("", ""))
+func (s *Session) UserAvatarDecode(u *User, options ...RequestOption) (img image.Image, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointUserAvatar(u.ID, u.Avatar), nil, EndpointUserAvatar("", ""), options...)
 	if err != nil {
 		return
 	}
@@ -382,10 +462,10 @@ func (s *Session) UserAvatarDecode(u *User) (img image.Image, err error) {
 }
 
 // UserUpdate updates a users settings.
-func (s *Session) UserUpdate(email, password, username, avatar, newPassword string) (st *User, err error) {
+func (s *Session) UserUpdate(email, password, username, avatar, newPassword string, options ...RequestOption) (st *User, err error) {
 	// NOTE: Avatar must be either the hash/id of existing Avatar or
 	// data:image/png;base64,BASE64_STRING_OF_NEW_AVATAR_PNG
-	// to set a new avatar.
+	// to set a new avatar, see ImageDataURI.
 	// If left blank, avatar will be set to null/blank
 	data := struct {
 		Email       string `json:"email,omitempty"`
@@ -395,7 +475,7 @@ func (s *Session) UserUpdate(email, password, username, avatar, newPassword stri
 		NewPassword string `json:"new_password,omitempty"`
 	}{email, password, username, avatar, newPassword}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointUser("@me"), data, EndpointUsers)
+	body, err := s.RequestWithBucketID("PATCH", EndpointUser("@me"), data, EndpointUsers, options...)
 	if err != nil {
 		return
 	}
@@ -405,8 +485,8 @@ func (s *Session) UserUpdate(email, password, username, avatar, newPassword stri
 }
 
 // UserSettings returns the settings for a given user
-func (s *Session) UserSettings() (st *Settings, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointUserSettings("@me"), nil, EndpointUserSettings(""))
+func (s *Session) UserSettings(options ...RequestOption) (st *Settings, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointUserSettings("@me"), nil, EndpointUserSettings(""), options...)
 	if err != nil {
 		return
 	}
@@ -417,7 +497,7 @@ func (s *Session) UserSettings() (st *Settings, err error) {
 
 // UserUpdateStatus update the user status
 // status   : The new status (Actual valid status are 'online','idle','dnd','invisible')
-func (s *Session) UserUpdateStatus(status Status) (st *Settings, err error) {
+func (s *Session) UserUpdateStatus(status Status, options ...RequestOption) (st *Settings, err error) {
 	if status == StatusOffline {
 		err = ErrStatusOffline
 		return
@@ -427,7 +507,7 @@ func (s *Session) UserUpdateStatus(status Status) (st *Settings, err error) {
 		Status Status `json:"status"`
 	}{status}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointUserSettings("@me"), data, EndpointUserSettings(""))
+	body, err := s.RequestWithBucketID("PATCH", EndpointUserSettings("@me"), data, EndpointUserSettings(""), options...)
 	if err != nil {
 		return
 	}
@@ -437,8 +517,8 @@ func (s *Session) UserUpdateStatus(status Status) (st *Settings, err error) {
 }
 
 // UserConnections returns the user's connections
-func (s *Session) UserConnections() (conn []*UserConnection, err error) {
-	response, err := s.RequestWithBucketID("GET", EndpointUserConnections("@me"), nil, EndpointUserConnections("@me"))
+func (s *Session) UserConnections(options ...RequestOption) (conn []*UserConnection, err error) {
+	response, err := s.RequestWithBucketID("GET", EndpointUserConnections("@me"), nil, EndpointUserConnections("@me"), options...)
 	if err != nil {
 		return nil, err
 	}
@@ -453,8 +533,8 @@ func (s *Session) UserConnections() (conn []*UserConnection, err error) {
 
 // UserChannels returns an array of Channel structures for all private
 // channels.
-func (s *Session) UserChannels() (st []*Channel, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointUserChannels("@me"), nil, EndpointUserChannels(""))
+func (s *Session) UserChannels(options ...RequestOption) (st []*Channel, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointUserChannels("@me"), nil, EndpointUserChannels(""), options...)
 	if err != nil {
 		return
 	}
@@ -465,12 +545,12 @@ func (s *Session) UserChannels() (st []*Channel, err error) {
 
 // UserChannelCreate creates a new User (Private) Channel with another User
 // recipientID : A user ID for the user to which this channel is opened with.
-func (s *Session) UserChannelCreate(recipientID string) (st *Channel, err error) {
+func (s *Session) UserChannelCreate(recipientID string, options ...RequestOption) (st *Channel, err error) {
 	data := struct {
 		RecipientID string `json:"recipient_id"`
 	}{recipientID}
 
-	body, err := s.RequestWithBucketID("POST", EndpointUserChannels("@me"), data, EndpointUserChannels(""))
+	body, err := s.RequestWithBucketID("POST", EndpointUserChannels("@me"), data, EndpointUserChannels(""), options...)
 	if err != nil {
 		return
 	}
@@ -483,7 +563,7 @@ func (s *Session) UserChannelCreate(recipientID string) (st *Channel, err error)
 // limit     : The number guilds that can be returned. (max 100)
 // beforeID  : If provided all guilds returned will be before given ID.
 // afterID   : If provided all guilds returned will be after given ID.
-func (s *Session) UserGuilds(limit int, beforeID, afterID string) (st []*UserGuild, err error) {
+func (s *Session) UserGuilds(limit int, beforeID, afterID string, options ...RequestOption) (st []*UserGuild, err error) {
 	v := url.Values{}
 
 	if limit > 0 {
@@ -502,7 +582,7 @@ func (s *Session) UserGuilds(limit int, beforeID, afterID string) (st []*UserGui
 		uri += "?" + v.Encode()
 	}
 
-	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointUserGuilds(""))
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointUserGuilds(""), options...)
 	if err != nil {
 		return
 	}
@@ -514,8 +594,8 @@ func (s *Session) UserGuilds(limit int, beforeID, afterID string) (st []*UserGui
 // UserGuildSettingsEdit Edits the users notification settings for a guild
 // guildID   : The ID of the guild to edit the settings on
 // settings  : The settings to update
-func (s *Session) UserGuildSettingsEdit(guildID string, settings *UserGuildSettingsEdit) (st *UserGuildSettings, err error) {
-	body, err := s.RequestWithBucketID("PATCH", EndpointUserGuildSettings("@me", guildID), settings, EndpointUserGuildSettings("", guildID))
+func (s *Session) UserGuildSettingsEdit(guildID string, settings *UserGuildSettingsEdit, options ...RequestOption) (st *UserGuildSettings, err error) {
+	body, err := s.RequestWithBucketID("PATCH", EndpointUserGuildSettings("@me", guildID), settings, EndpointUserGuildSettings("", guildID), options...)
 	if err != nil {
 		return
 	}
@@ -530,7 +610,7 @@ func (s *Session) UserGuildSettingsEdit(guildID string, settings *UserGuildSetti
 //
 // NOTE: This function is now deprecated and will be removed in the future.
 // Please see the same function inside state.go
-func (s *Session) UserChannelPermissions(userID, channelID string) (apermissions int, err error) {
+func (s *Session) UserChannelPermissions(userID, channelID string, options ...RequestOption) (apermissions Permissions, err error) {
 	// Try to just get permissions from state.
 	apermissions, err = s.State.UserChannelPermissions(userID, channelID)
 	if err == nil {
@@ -540,15 +620,26 @@ func (s *Session) UserChannelPermissions(userID, channelID string) (apermissions
 	// Otherwise try get as much data from state as possible, falling back to the network.
 	channel, err := s.State.Channel(channelID)
 	if err != nil || channel == nil {
-		channel, err = s.Channel(channelID)
+		channel, err = s.Channel(channelID, options...)
 		if err != nil {
 			return
 		}
 	}
 
+	var parent *Channel
+	if channel.IsThread() {
+		parent, err = s.State.Channel(channel.ParentID)
+		if err != nil || parent == nil {
+			parent, err = s.Channel(channel.ParentID, options...)
+			if err != nil {
+				return
+			}
+		}
+	}
+
 	guild, err := s.State.Guild(channel.GuildID)
 	if err != nil || guild == nil {
-		guild, err = s.Guild(channel.GuildID)
+		guild, err = s.Guild(channel.GuildID, options...)
 		if err != nil {
 			return
 		}
@@ -561,84 +652,13 @@ func (s *Session) UserChannelPermissions(userID, channelID string) (apermissions
 
 	member, err := s.State.Member(guild.ID, userID)
 	if err != nil || member == nil {
-		member, err = s.GuildMember(guild.ID, userID)
+		member, err = s.GuildMember(guild.ID, userID, options...)
 		if err != nil {
 			return
 		}
 	}
 
-	return memberPermissions(guild, channel, member), nil
-}
-
-// Calculates the permissions for a member.
-// https://support.discordapp.com/hc/en-us/articles/206141927-How-is-the-permission-hierarchy-structured-
-func memberPermissions(guild *Guild, channel *Channel, member *Member) (apermissions int) {
-	userID := member.User.ID
-
-	if userID == guild.OwnerID {
-		apermissions = PermissionAll
-		return
-	}
-
-	for _, role := range guild.Roles {
-		if role.ID == guild.ID {
-			apermissions |= role.Permissions
-			break
-		}
-	}
-
-	for _, role := range guild.Roles {
-		for _, roleID := range member.Roles {
-			if role.ID == roleID {
-				apermissions |= role.Permissions
-				break
-			}
-		}
-	}
-
-	if apermissions&PermissionAdministrator == PermissionAdministrator {
-		apermissions |= PermissionAll
-	}
-
-	// Apply @everyone overrides from the channel.
-	for _, overwrite := range channel.PermissionOverwrites {
-		if guild.ID == overwrite.ID {
-			apermissions &= ^overwrite.Deny
-			apermissions |= overwrite.Allow
-			break
-		}
-	}
-
-	denies := 0
-	allows := 0
-
-	// Member overwrites can override role overrides, so do two passes
-	for _, overwrite := range channel.PermissionOverwrites {
-		for _, roleID := range member.Roles {
-			if overwrite.Type == PermissionOverwriteTypeRole && roleID == overwrite.ID {
-				denies |= overwrite.Deny
-				allows |= overwrite.Allow
-				break
-			}
-		}
-	}
-
-	apermissions &= ^denies
-	apermissions |= allows
-
-	for _, overwrite := range channel.PermissionOverwrites {
-		if overwrite.Type == PermissionOverwriteTypeMember && overwrite.ID == userID {
-			apermissions &= ^overwrite.Deny
-			apermissions |= overwrite.Allow
-			break
-		}
-	}
-
-	if apermissions&PermissionAdministrator == PermissionAdministrator {
-		apermissions |= PermissionAllChannel
-	}
-
-	return apermissions
+	return MemberPermissions(guild, channel, parent, member)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -647,7 +667,7 @@ func memberPermissions(guild *Guild, channel *Channel, member *Member) (apermiss
 
 // Guild returns a Guild structure of a specific Guild.
 // guildID   : The ID of a Guild
-func (s *Session) Guild(guildID string) (st *Guild, err error) {
+func (s *Session) Guild(guildID string, options ...RequestOption) (st *Guild, err error) {
 	if s.StateEnabled {
 		// Attempt to grab the guild from State first.
 		st, err = s.State.Guild(guildID)
@@ -656,7 +676,7 @@ func (s *Session) Guild(guildID string) (st *Guild, err error) {
 		}
 	}
 
-	body, err := s.RequestWithBucketID("GET", EndpointGuild(guildID), nil, EndpointGuild(guildID))
+	body, err := s.RequestWithBucketID("GET", EndpointGuild(guildID), nil, EndpointGuild(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -667,8 +687,8 @@ func (s *Session) Guild(guildID string) (st *Guild, err error) {
 
 // GuildWithCounts returns a Guild structure of a specific Guild with extra approximate member and presence counts.
 // guildID   : The ID of a Guild
-func (s *Session) GuildWithCounts(guildID string) (st *Guild, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuild(guildID)+"?with_counts=true", nil, EndpointGuild(guildID))
+func (s *Session) GuildWithCounts(guildID string, options ...RequestOption) (st *Guild, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuild(guildID)+"?with_counts=true", nil, EndpointGuild(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -679,8 +699,8 @@ func (s *Session) GuildWithCounts(guildID string) (st *Guild, err error) {
 
 // GuildPreview returns a GuildPreview structure of a specific Discoverable Guild.
 // guildID   : The ID of a Guild
-func (s *Session) GuildPreview(guildID string) (gp *GuildPreview, err error) {
-	body, err := s.Request("GET", EndpointGuildPreview(guildID), nil)
+func (s *Session) GuildPreview(guildID string, options ...RequestOption) (gp *GuildPreview, err error) {
+	body, err := s.Request("GET", EndpointGuildPreview(guildID), nil, options...)
 	if err != nil {
 		return
 	}
@@ -691,12 +711,12 @@ func (s *Session) GuildPreview(guildID string) (gp *GuildPreview, err error) {
 
 // GuildCreate creates a new Guild
 // name      : A name for the Guild (2-100 characters)
-func (s *Session) GuildCreate(name string) (st *Guild, err error) {
+func (s *Session) GuildCreate(name string, options ...RequestOption) (st *Guild, err error) {
 	data := struct {
 		Name string `json:"name"`
 	}{name}
 
-	body, err := s.RequestWithBucketID("POST", EndpointGuildCreate, data, EndpointGuildCreate)
+	body, err := s.RequestWithBucketID("POST", EndpointGuildCreate, data, EndpointGuildCreate, options...)
 	if err != nil {
 		return
 	}
@@ -708,7 +728,7 @@ func (s *Session) GuildCreate(name string) (st *Guild, err error) {
 // GuildEdit edits a new Guild
 // guildID   : The ID of a Guild
 // g 		 : A GuildParams struct with the values Name, Region and VerificationLevel defined.
-func (s *Session) GuildEdit(guildID string, g GuildParams) (st *Guild, err error) {
+func (s *Session) GuildEdit(guildID string, g GuildParams, options ...RequestOption) (st *Guild, err error) {
 	// Bounds checking for VerificationLevel, interval: [0, 4]
 	if g.VerificationLevel != nil {
 		val := *g.VerificationLevel
@@ -721,7 +741,7 @@ func (s *Session) GuildEdit(guildID string, g GuildParams) (st *Guild, err error
 	//Bounds checking for regions
 	if g.Region != "" {
 		isValid := false
-		regions, _ := s.VoiceRegions()
+		regions, _ := s.VoiceRegions(options...)
 		for _, r := range regions {
 			if g.Region == r.ID {
 				isValid = true
@@ -737,7 +757,7 @@ func (s *Session) GuildEdit(guildID string, g GuildParams) (st *Guild, err error
 		}
 	}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuild(guildID), g, EndpointGuild(guildID))
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuild(guildID), g, EndpointGuild(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -748,8 +768,8 @@ func (s *Session) GuildEdit(guildID string, g GuildParams) (st *Guild, err error
 
 // GuildDelete deletes a Guild.
 // guildID   : The ID of a Guild
-func (s *Session) GuildDelete(guildID string) (st *Guild, err error) {
-	body, err := s.RequestWithBucketID("DELETE", EndpointGuild(guildID), nil, EndpointGuild(guildID))
+func (s *Session) GuildDelete(guildID string, options ...RequestOption) (st *Guild, err error) {
+	body, err := s.RequestWithBucketID("DELETE", EndpointGuild(guildID), nil, EndpointGuild(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -760,16 +780,37 @@ func (s *Session) GuildDelete(guildID string) (st *Guild, err error) {
 
 // GuildLeave leaves a Guild.
 // guildID   : The ID of a Guild
-func (s *Session) GuildLeave(guildID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointUserGuild("@me", guildID), nil, EndpointUserGuild("", guildID))
+func (s *Session) GuildLeave(guildID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointUserGuild("@me", guildID), nil, EndpointUserGuild("", guildID), options...)
 	return
 }
 
 // GuildBans returns an array of GuildBan structures for all bans of a
 // given guild.
 // guildID   : The ID of a Guild.
-func (s *Session) GuildBans(guildID string) (st []*GuildBan, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildBans(guildID), nil, EndpointGuildBans(guildID))
+func (s *Session) GuildBans(guildID string, options ...RequestOption) (st []*GuildBan, err error) {
+	return s.guildBans(guildID, 0, "", "", options...)
+}
+
+// guildBans returns a page of the bans of a guild, newer API versions support paging.
+func (s *Session) guildBans(guildID string, limit int, beforeID, afterID string, options ...RequestOption) (st []*GuildBan, err error) {
+	uri := EndpointGuildBans(guildID)
+
+	v := url.Values{}
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+	if beforeID != "" {
+		v.Set("before", beforeID)
+	}
+	if afterID != "" {
+		v.Set("after", afterID)
+	}
+	if len(v) > 0 {
+		uri += "?" + v.Encode()
+	}
+
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildBans(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -781,8 +822,8 @@ func (s *Session) GuildBans(guildID string) (st []*GuildBan, err error) {
 // GuildBans returns a GuildBan structures for specified user in a guild.
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User.
-func (s *Session) GuildBan(guildID string, userID string) (ban *GuildBan, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildBan(guildID, userID), nil, EndpointGuildBan(guildID, ""))
+func (s *Session) GuildBan(guildID string, userID string, options ...RequestOption) (ban *GuildBan, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildBan(guildID, userID), nil, EndpointGuildBan(guildID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -795,8 +836,8 @@ func (s *Session) GuildBan(guildID string, userID string) (ban *GuildBan, err er
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User.
 // days      : The number of days of previous comments to delete.
-func (s *Session) GuildBanCreate(guildID, userID string, days int) (err error) {
-	return s.GuildBanCreateWithReason(guildID, userID, "", days)
+func (s *Session) GuildBanCreate(guildID, userID string, days int, options ...RequestOption) (err error) {
+	return s.GuildBanCreateWithReason(guildID, userID, "", days, options...)
 }
 
 // GuildBanCreateWithReason bans the given user from the given guild also providing a reason.
@@ -804,30 +845,28 @@ func (s *Session) GuildBanCreate(guildID, userID string, days int) (err error) {
 // userID    : The ID of a User
 // reason    : The reason for this ban
 // days      : The number of days of previous comments to delete (0-7).
-func (s *Session) GuildBanCreateWithReason(guildID, userID, reason string, days int) (err error) {
+func (s *Session) GuildBanCreateWithReason(guildID, userID, reason string, days int, options ...RequestOption) (err error) {
 	uri := EndpointGuildBan(guildID, userID)
 
 	queryParams := url.Values{}
 	if days > 0 {
 		queryParams.Set("delete_message_days", strconv.Itoa(days))
 	}
-	if reason != "" {
-		queryParams.Set("reason", reason)
-	}
 
 	if len(queryParams) > 0 {
 		uri += "?" + queryParams.Encode()
 	}
 
-	_, err = s.RequestWithBucketID("PUT", uri, nil, EndpointGuildBan(guildID, ""))
+	options = append([]RequestOption{WithAuditLogReason(reason)}, options...)
+	_, err = s.RequestWithBucketID("PUT", uri, nil, EndpointGuildBan(guildID, ""), options...)
 	return
 }
 
 // GuildBanDelete removes the given user from the guild bans
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User
-func (s *Session) GuildBanDelete(guildID, userID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildBan(guildID, userID), nil, EndpointGuildBan(guildID, ""))
+func (s *Session) GuildBanDelete(guildID, userID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildBan(guildID, userID), nil, EndpointGuildBan(guildID, ""), options...)
 	return
 }
 
@@ -835,7 +874,7 @@ func (s *Session) GuildBanDelete(guildID, userID string) (err error) {
 //  guildID  : The ID of a Guild.
 //  after    : The id of the member to return members after
 //  limit    : max number of members to return (max 1000)
-func (s *Session) GuildMembers(guildID string, after string, limit int) (st []*Member, err error) {
+func (s *Session) GuildMembers(guildID string, after string, limit int, options ...RequestOption) (st []*Member, err error) {
 	uri := EndpointGuildMembers(guildID)
 	v := url.Values{}
 
@@ -851,7 +890,7 @@ func (s *Session) GuildMembers(guildID string, after string, limit int) (st []*M
 		uri += "?" + v.Encode()
 	}
 
-	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildMembers(guildID))
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildMembers(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -863,8 +902,8 @@ func (s *Session) GuildMembers(guildID string, after string, limit int) (st []*M
 // GuildMember returns a member of a guild.
 //  guildID   : The ID of a Guild.
 //  userID    : The ID of a User
-func (s *Session) GuildMember(guildID, userID string) (st *Member, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildMember(guildID, userID), nil, EndpointGuildMember(guildID, ""))
+func (s *Session) GuildMember(guildID, userID string, options ...RequestOption) (st *Member, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildMember(guildID, userID), nil, EndpointGuildMember(guildID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -881,7 +920,7 @@ func (s *Session) GuildMember(guildID, userID string) (st *Member, err error) {
 //  roles         : A list of role ID's to set on the member.
 //  mute          : If the user is muted.
 //  deaf          : If the user is deafened.
-func (s *Session) GuildMemberAdd(accessToken, guildID, userID, nick string, roles []string, mute, deaf bool) (err error) {
+func (s *Session) GuildMemberAdd(accessToken, guildID, userID, nick string, roles []string, mute, deaf bool, options ...RequestOption) (err error) {
 	data := struct {
 		AccessToken string   `json:"access_token"`
 		Nick        string   `json:"nick,omitempty"`
@@ -890,7 +929,7 @@ func (s *Session) GuildMemberAdd(accessToken, guildID, userID, nick string, role
 		Deaf        bool     `json:"deaf,omitempty"`
 	}{accessToken, nick, roles, mute, deaf}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
+	_, err = s.RequestWithBucketID("PUT", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""), options...)
 	if err != nil {
 		return err
 	}
@@ -901,21 +940,17 @@ func (s *Session) GuildMemberAdd(accessToken, guildID, userID, nick string, role
 // GuildMemberDelete removes the given user from the given guild.
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User
-func (s *Session) GuildMemberDelete(guildID, userID string) (err error) {
-	return s.GuildMemberDeleteWithReason(guildID, userID, "")
+func (s *Session) GuildMemberDelete(guildID, userID string, options ...RequestOption) (err error) {
+	return s.GuildMemberDeleteWithReason(guildID, userID, "", options...)
 }
 
 // GuildMemberDeleteWithReason removes the given user from the given guild.
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User
 // reason    : The reason for the kick
-func (s *Session) GuildMemberDeleteWithReason(guildID, userID, reason string) (err error) {
-	uri := EndpointGuildMember(guildID, userID)
-	if reason != "" {
-		uri += "?reason=" + url.QueryEscape(reason)
-	}
-
-	_, err = s.RequestWithBucketID("DELETE", uri, nil, EndpointGuildMember(guildID, ""))
+func (s *Session) GuildMemberDeleteWithReason(guildID, userID, reason string, options ...RequestOption) (err error) {
+	options = append([]RequestOption{WithAuditLogReason(reason)}, options...)
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildMember(guildID, userID), nil, EndpointGuildMember(guildID, ""), options...)
 	return
 }
 
@@ -931,8 +966,8 @@ type MemberEditData struct {
 // guildID  : The ID of a Guild.
 // userID   : The ID of a User.
 // data     : A structure containing data to edit.
-func (s *Session) GuildMemberEditComplex(guildID, userID string, data *MemberEditData) (err error) {
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
+func (s *Session) GuildMemberEditComplex(guildID, userID string, data *MemberEditData, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -944,8 +979,8 @@ func (s *Session) GuildMemberEditComplex(guildID, userID string, data *MemberEdi
 // guildID  : The ID of a Guild.
 // userID   : The ID of a User.
 // roles    : A list of role ID's to set on the member.
-func (s *Session) GuildMemberEdit(guildID, userID string, roles []string) (err error) {
-	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{Roles: roles})
+func (s *Session) GuildMemberEdit(guildID, userID string, roles []string, options ...RequestOption) (err error) {
+	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{Roles: roles}, options...)
 }
 
 // GuildMemberMove moves a guild member from one voice channel to another/none
@@ -954,43 +989,43 @@ func (s *Session) GuildMemberEdit(guildID, userID string, roles []string) (err e
 //  channelID : The ID of a channel to move user to, or null?
 // NOTE : I am not entirely set on the name of this function and it may change
 // prior to the final 1.0.0 release of Discordgo
-func (s *Session) GuildMemberMove(guildID, userID, channelID string) (err error) {
-	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{ChannelID: channelID})
+func (s *Session) GuildMemberMove(guildID, userID, channelID string, options ...RequestOption) (err error) {
+	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{ChannelID: channelID}, options...)
 }
 
 // GuildMemberNickname updates the nickname of a guild member
 // guildID   : The ID of a guild
 // userID    : The ID of a user
 // userID    : The ID of a user or "@me" which is a shortcut of the current user ID
-func (s *Session) GuildMemberNickname(guildID, userID, nickname string) (err error) {
+func (s *Session) GuildMemberNickname(guildID, userID, nickname string, options ...RequestOption) (err error) {
 	if userID == "@me" {
 		userID += "/nick"
 	}
-	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{Nick: nickname})
+	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{Nick: nickname}, options...)
 }
 
 // GuildMemberMute change the mute status of specified member.
 // guildID  : The ID of a Guild.
 // userID   : The ID of a User.
 // mute     : Is the member supposed to be muted.
-func (s *Session) GuildMemberMute(guildID, userID string, mute bool) (err error) {
-	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{Mute: &mute})
+func (s *Session) GuildMemberMute(guildID, userID string, mute bool, options ...RequestOption) (err error) {
+	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{Mute: &mute}, options...)
 }
 
 // GuildMemberDeaf change the deaf status of specified member.
 // guildID  : The ID of a Guild.
 // userID   : The ID of a User.
 // deaf     : Is the member supposed to be deaf.
-func (s *Session) GuildMemberDeaf(guildID, userID string, deaf bool) (err error) {
-	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{Deaf: &deaf})
+func (s *Session) GuildMemberDeaf(guildID, userID string, deaf bool, options ...RequestOption) (err error) {
+	return s.GuildMemberEditComplex(guildID, userID, &MemberEditData{Deaf: &deaf}, options...)
 }
 
 // GuildMemberRoleAdd adds the specified role to a given member
 //  guildID   : The ID of a Guild.
 //  userID    : The ID of a User.
 //  roleID 	  : The ID of a Role to be assigned to the user.
-func (s *Session) GuildMemberRoleAdd(guildID, userID, roleID string) (err error) {
-	_, err = s.RequestWithBucketID("PUT", EndpointGuildMemberRole(guildID, userID, roleID), nil, EndpointGuildMemberRole(guildID, "", ""))
+func (s *Session) GuildMemberRoleAdd(guildID, userID, roleID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("PUT", EndpointGuildMemberRole(guildID, userID, roleID), nil, EndpointGuildMemberRole(guildID, "", ""), options...)
 	return
 }
 
@@ -998,16 +1033,16 @@ func (s *Session) GuildMemberRoleAdd(guildID, userID, roleID string) (err error)
 //  guildID   : The ID of a Guild.
 //  userID    : The ID of a User.
 //  roleID 	  : The ID of a Role to be removed from the user.
-func (s *Session) GuildMemberRoleRemove(guildID, userID, roleID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildMemberRole(guildID, userID, roleID), nil, EndpointGuildMemberRole(guildID, "", ""))
+func (s *Session) GuildMemberRoleRemove(guildID, userID, roleID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildMemberRole(guildID, userID, roleID), nil, EndpointGuildMemberRole(guildID, "", ""), options...)
 	return
 }
 
 // GuildChannels returns an array of Channel structures for all channels of a
 // given guild.
 // guildID   : The ID of a Guild.
-func (s *Session) GuildChannels(guildID string) (st []*Channel, err error) {
-	body, err := s.request("GET", EndpointGuildChannels(guildID), "", nil, EndpointGuildChannels(guildID), 0)
+func (s *Session) GuildChannels(guildID string, options ...RequestOption) (st []*Channel, err error) {
+	body, err := s.request("GET", EndpointGuildChannels(guildID), "", nil, EndpointGuildChannels(guildID), 0, options...)
 	if err != nil {
 		return
 	}
@@ -1033,8 +1068,8 @@ type GuildChannelCreateData struct {
 // GuildChannelCreateComplex creates a new channel in the given guild
 // guildID      : The ID of a Guild
 // data         : A data struct describing the new Channel, Name and Type are mandatory, other fields depending on the type
-func (s *Session) GuildChannelCreateComplex(guildID string, data GuildChannelCreateData) (st *Channel, err error) {
-	body, err := s.RequestWithBucketID("POST", EndpointGuildChannels(guildID), data, EndpointGuildChannels(guildID))
+func (s *Session) GuildChannelCreateComplex(guildID string, data GuildChannelCreateData, options ...RequestOption) (st *Channel, err error) {
+	body, err := s.RequestWithBucketID("POST", EndpointGuildChannels(guildID), data, EndpointGuildChannels(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1047,17 +1082,17 @@ func (s *Session) GuildChannelCreateComplex(guildID string, data GuildChannelCre
 // guildID   : The ID of a Guild.
 // name      : Name of the channel (2-100 chars length)
 // ctype     : Type of the channel
-func (s *Session) GuildChannelCreate(guildID, name string, ctype ChannelType) (st *Channel, err error) {
+func (s *Session) GuildChannelCreate(guildID, name string, ctype ChannelType, options ...RequestOption) (st *Channel, err error) {
 	return s.GuildChannelCreateComplex(guildID, GuildChannelCreateData{
 		Name: name,
 		Type: ctype,
-	})
+	}, options...)
 }
 
 // GuildChannelsReorder updates the order of channels in a guild
 // guildID   : The ID of a Guild.
 // channels  : Updated channels.
-func (s *Session) GuildChannelsReorder(guildID string, channels []*Channel) (err error) {
+func (s *Session) GuildChannelsReorder(guildID string, channels []*Channel, options ...RequestOption) (err error) {
 	data := make([]struct {
 		ID       string `json:"id"`
 		Position int    `json:"position"`
@@ -1068,14 +1103,14 @@ func (s *Session) GuildChannelsReorder(guildID string, channels []*Channel) (err
 		data[i].Position = c.Position
 	}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildChannels(guildID), data, EndpointGuildChannels(guildID))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildChannels(guildID), data, EndpointGuildChannels(guildID), options...)
 	return
 }
 
 // GuildInvites returns an array of Invite structures for the given guild
 // guildID   : The ID of a Guild.
-func (s *Session) GuildInvites(guildID string) (st []*Invite, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildInvites(guildID), nil, EndpointGuildInvites(guildID))
+func (s *Session) GuildInvites(guildID string, options ...RequestOption) (st []*Invite, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildInvites(guildID), nil, EndpointGuildInvites(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1086,8 +1121,8 @@ func (s *Session) GuildInvites(guildID string) (st []*Invite, err error) {
 
 // GuildRoles returns all roles for a given guild.
 // guildID   : The ID of a Guild.
-func (s *Session) GuildRoles(guildID string) (st []*Role, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildRoles(guildID), nil, EndpointGuildRoles(guildID))
+func (s *Session) GuildRoles(guildID string, options ...RequestOption) (st []*Role, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildRoles(guildID), nil, EndpointGuildRoles(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1097,18 +1132,18 @@ func (s *Session) GuildRoles(guildID string) (st []*Role, err error) {
 }
 
 type GuildRoleData struct {
-	Name        string `json:"name,omitempty"`
-	Permissions int    `json:"permissions,string,omitempty"`
-	Color       int    `json:"color,omitempty"`
-	Hoist       *bool  `json:"hoist,omitempty"`
-	Mentionable *bool  `json:"mentionable,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	Permissions Permissions `json:"permissions,omitempty"`
+	Color       int         `json:"color,omitempty"`
+	Hoist       *bool       `json:"hoist,omitempty"`
+	Mentionable *bool       `json:"mentionable,omitempty"`
 }
 
 // GuildRoleCreateComplex returns a new Guild Role
 // guildID: The ID of a Guild.
 // data   : Role initial information
-func (s *Session) GuildRoleCreateComplex(guildID string, data *GuildRoleData) (rr *Role, err error) {
-	body, err := s.RequestWithBucketID("POST", EndpointGuildRoles(guildID), data, EndpointGuildRoles(guildID))
+func (s *Session) GuildRoleCreateComplex(guildID string, data *GuildRoleData, options ...RequestOption) (rr *Role, err error) {
+	body, err := s.RequestWithBucketID("POST", EndpointGuildRoles(guildID), data, EndpointGuildRoles(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1119,8 +1154,8 @@ func (s *Session) GuildRoleCreateComplex(guildID string, data *GuildRoleData) (r
 
 // GuildRoleCreate returns a new Guild Role.
 // guildID: The ID of a Guild.
-func (s *Session) GuildRoleCreate(guildID string) (st *Role, err error) {
-	st, err = s.GuildRoleCreateComplex(guildID, nil)
+func (s *Session) GuildRoleCreate(guildID string, options ...RequestOption) (st *Role, err error) {
+	st, err = s.GuildRoleCreateComplex(guildID, nil, options...)
 	return
 }
 
@@ -1132,7 +1167,7 @@ func (s *Session) GuildRoleCreate(guildID string) (st *Role, err error) {
 // hoist     : Whether to display the role's users separately.
 // perm      : The permissions for the role.
 // mention   : Whether this role is mentionable
-func (s *Session) GuildRoleEdit(guildID, roleID, name string, color int, hoist bool, perm int, mention bool) (st *Role, err error) {
+func (s *Session) GuildRoleEdit(guildID, roleID, name string, color int, hoist bool, perm Permissions, mention bool, options ...RequestOption) (st *Role, err error) {
 	// Prevent sending a color int that is too big.
 	if color > 0xFFFFFF {
 		return nil, fmt.Errorf("color value cannot be larger than 0xFFFFFF")
@@ -1145,7 +1180,7 @@ func (s *Session) GuildRoleEdit(guildID, roleID, name string, color int, hoist b
 		Mentionable: &mention,
 	}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuildRole(guildID, roleID), data, EndpointGuildRole(guildID, ""))
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildRole(guildID, roleID), data, EndpointGuildRole(guildID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1157,8 +1192,8 @@ func (s *Session) GuildRoleEdit(guildID, roleID, name string, color int, hoist b
 // GuildRoleReorder reorders guild roles
 // guildID   : The ID of a Guild.
 // roles     : A list of ordered roles.
-func (s *Session) GuildRoleReorder(guildID string, roles []*Role) (st []*Role, err error) {
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuildRoles(guildID), roles, EndpointGuildRoles(guildID))
+func (s *Session) GuildRoleReorder(guildID string, roles []*Role, options ...RequestOption) (st []*Role, err error) {
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildRoles(guildID), roles, EndpointGuildRoles(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1170,8 +1205,8 @@ func (s *Session) GuildRoleReorder(guildID string, roles []*Role) (st []*Role, e
 // GuildRoleDelete deletes an existing role.
 // guildID   : The ID of a Guild.
 // roleID    : The ID of a Role.
-func (s *Session) GuildRoleDelete(guildID, roleID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildRole(guildID, roleID), nil, EndpointGuildRole(guildID, ""))
+func (s *Session) GuildRoleDelete(guildID, roleID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildRole(guildID, roleID), nil, EndpointGuildRole(guildID, ""), options...)
 	return
 }
 
@@ -1179,7 +1214,7 @@ func (s *Session) GuildRoleDelete(guildID, roleID string) (err error) {
 // Requires 'KICK_MEMBER' permission.
 // guildID	: The ID of a Guild.
 // days		: The number of days to count prune for (1 or more).
-func (s *Session) GuildPruneCount(guildID string, days uint32) (count uint32, err error) {
+func (s *Session) GuildPruneCount(guildID string, days uint32, options ...RequestOption) (count uint32, err error) {
 	count = 0
 	if days <= 0 {
 		err = ErrPruneDaysBounds
@@ -1191,7 +1226,7 @@ func (s *Session) GuildPruneCount(guildID string, days uint32) (count uint32, er
 	}{}
 
 	uri := EndpointGuildPrune(guildID) + "?days=" + strconv.FormatUint(uint64(days), 10)
-	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildPrune(guildID))
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildPrune(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1209,7 +1244,7 @@ func (s *Session) GuildPruneCount(guildID string, days uint32) (count uint32, er
 // Returns an object with one 'pruned' key indicating the number of members that were removed in the prune operation.
 // guildID	: The ID of a Guild.
 // days		: The number of days to count prune for (1 or more).
-func (s *Session) GuildPrune(guildID string, days uint32) (count uint32, err error) {
+func (s *Session) GuildPrune(guildID string, days uint32, options ...RequestOption) (count uint32, err error) {
 	count = 0
 	if days <= 0 {
 		err = ErrPruneDaysBounds
@@ -1223,7 +1258,7 @@ func (s *Session) GuildPrune(guildID string, days uint32) (count uint32, err err
 		Pruned uint32 `json:"pruned"`
 	}{}
 
-	body, err := s.RequestWithBucketID("POST", EndpointGuildPrune(guildID), data, EndpointGuildPrune(guildID))
+	body, err := s.RequestWithBucketID("POST", EndpointGuildPrune(guildID), data, EndpointGuildPrune(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1239,8 +1274,8 @@ func (s *Session) GuildPrune(guildID string, days uint32) (count uint32, err err
 
 // GuildVoiceRegions returns an array of VoiceRegion structures for a guild.
 // guildID   : The ID of a Guild.
-func (s *Session) GuildVoiceRegions(guildID string) (vr []*VoiceRegion, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildVoiceRegions(guildID), nil, EndpointGuildVoiceRegions(guildID))
+func (s *Session) GuildVoiceRegions(guildID string, options ...RequestOption) (vr []*VoiceRegion, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildVoiceRegions(guildID), nil, EndpointGuildVoiceRegions(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1251,8 +1286,8 @@ func (s *Session) GuildVoiceRegions(guildID string) (vr []*VoiceRegion, err erro
 
 // GuildIntegrations returns an array of Integrations for a guild.
 // guildID   : The ID of a Guild.
-func (s *Session) GuildIntegrations(guildID string) (st []*Integration, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildIntegrations(guildID), nil, EndpointGuildIntegrations(guildID))
+func (s *Session) GuildIntegrations(guildID string, options ...RequestOption) (st []*Integration, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildIntegrations(guildID), nil, EndpointGuildIntegrations(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1265,13 +1300,13 @@ func (s *Session) GuildIntegrations(guildID string) (st []*Integration, err erro
 // guildID          : The ID of a Guild.
 // integrationType  : The Integration type.
 // integrationID    : The ID of an integration.
-func (s *Session) GuildIntegrationCreate(guildID, integrationType, integrationID string) (err error) {
+func (s *Session) GuildIntegrationCreate(guildID, integrationType, integrationID string, options ...RequestOption) (err error) {
 	data := struct {
 		Type string `json:"type"`
 		ID   string `json:"id"`
 	}{integrationType, integrationID}
 
-	_, err = s.RequestWithBucketID("POST", EndpointGuildIntegrations(guildID), data, EndpointGuildIntegrations(guildID))
+	_, err = s.RequestWithBucketID("POST", EndpointGuildIntegrations(guildID), data, EndpointGuildIntegrations(guildID), options...)
 	return
 }
 
@@ -1282,37 +1317,37 @@ func (s *Session) GuildIntegrationCreate(guildID, integrationType, integrationID
 // expireBehavior	      : The behavior when an integration subscription lapses (see the integration object documentation).
 // expireGracePeriod    : Period (in seconds) where the integration will ignore lapsed subscriptions.
 // enableEmoticons	    : Whether emoticons should be synced for this integration (twitch only currently).
-func (s *Session) GuildIntegrationEdit(guildID, integrationID string, expireBehavior, expireGracePeriod int, enableEmoticons bool) (err error) {
+func (s *Session) GuildIntegrationEdit(guildID, integrationID string, expireBehavior, expireGracePeriod int, enableEmoticons bool, options ...RequestOption) (err error) {
 	data := struct {
 		ExpireBehavior    int  `json:"expire_behavior"`
 		ExpireGracePeriod int  `json:"expire_grace_period"`
 		EnableEmoticons   bool `json:"enable_emoticons"`
 	}{expireBehavior, expireGracePeriod, enableEmoticons}
 
-	_, err = s.RequestWithBucketID("PATCH", EndpointGuildIntegration(guildID, integrationID), data, EndpointGuildIntegration(guildID, ""))
+	_, err = s.RequestWithBucketID("PATCH", EndpointGuildIntegration(guildID, integrationID), data, EndpointGuildIntegration(guildID, ""), options...)
 	return
 }
 
 // GuildIntegrationDelete removes the given integration from the Guild.
 // guildID          : The ID of a Guild.
 // integrationID    : The ID of an integration.
-func (s *Session) GuildIntegrationDelete(guildID, integrationID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildIntegration(guildID, integrationID), nil, EndpointGuildIntegration(guildID, ""))
+func (s *Session) GuildIntegrationDelete(guildID, integrationID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildIntegration(guildID, integrationID), nil, EndpointGuildIntegration(guildID, ""), options...)
 	return
 }
 
 // GuildIntegrationSync syncs an integration.
 // guildID          : The ID of a Guild.
 // integrationID    : The ID of an integration.
-func (s *Session) GuildIntegrationSync(guildID, integrationID string) (err error) {
-	_, err = s.RequestWithBucketID("POST", EndpointGuildIntegrationSync(guildID, integrationID), nil, EndpointGuildIntegration(guildID, ""))
+func (s *Session) GuildIntegrationSync(guildID, integrationID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("POST", EndpointGuildIntegrationSync(guildID, integrationID), nil, EndpointGuildIntegration(guildID, ""), options...)
 	return
 }
 
 // GuildVanityURL returns a partial Invite structure with the vanity url code for a Guild
 // guildID   : The ID of a Guild
-func (s *Session) GuildVanityURL(guildID string) (in *Invite, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildVanityURL(guildID), nil, EndpointGuildVanityURL(guildID))
+func (s *Session) GuildVanityURL(guildID string, options ...RequestOption) (in *Invite, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildVanityURL(guildID), nil, EndpointGuildVanityURL(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1323,8 +1358,8 @@ func (s *Session) GuildVanityURL(guildID string) (in *Invite, err error) {
 
 // GuildIcon returns an image.Image of a guild icon.
 // guildID   : The ID of a Guild.
-func (s *Session) GuildIcon(guildID string) (img image.Image, err error) {
-	g, err := s.Guild(guildID)
+func (s *Session) GuildIcon(guildID string, options ...RequestOption) (img image.Image, err error) {
+	g, err := s.Guild(guildID, options...)
 	if err != nil {
 		return
 	}
@@ -1334,7 +1369,7 @@ func (s *Session) GuildIcon(guildID string) (img image.Image, err error) {
 		return
 	}
 
-	body, err := s.RequestWithBucketID("GET", EndpointGuildIcon(guildID, g.Icon), nil, EndpointGuildIcon(guildID, ""))
+	body, err := s.RequestWithBucketID("GET", EndpointGuildIcon(guildID, g.Icon), nil, EndpointGuildIcon(guildID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1345,8 +1380,8 @@ func (s *Session) GuildIcon(guildID string) (img image.Image, err error) {
 
 // GuildSplash returns an image.Image of a guild splash image.
 // guildID   : The ID of a Guild.
-func (s *Session) GuildSplash(guildID string) (img image.Image, err error) {
-	g, err := s.Guild(guildID)
+func (s *Session) GuildSplash(guildID string, options ...RequestOption) (img image.Image, err error) {
+	g, err := s.Guild(guildID, options...)
 	if err != nil {
 		return
 	}
@@ -1356,7 +1391,7 @@ func (s *Session) GuildSplash(guildID string) (img image.Image, err error) {
 		return
 	}
 
-	body, err := s.RequestWithBucketID("GET", EndpointGuildSplash(guildID, g.Splash), nil, EndpointGuildSplash(guildID, ""))
+	body, err := s.RequestWithBucketID("GET", EndpointGuildSplash(guildID, g.Splash), nil, EndpointGuildSplash(guildID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1367,8 +1402,8 @@ func (s *Session) GuildSplash(guildID string) (img image.Image, err error) {
 
 // GuildWidget returns the widget for a Guild.
 // guildID   : The ID of a Guild.
-func (s *Session) GuildWidget(guildID string) (st *GuildWidget, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildWidget(guildID), nil, EndpointGuildWidget(guildID))
+func (s *Session) GuildWidget(guildID string, options ...RequestOption) (st *GuildWidget, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildWidget(guildID), nil, EndpointGuildWidget(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1381,9 +1416,9 @@ func (s *Session) GuildWidget(guildID string) (st *GuildWidget, err error) {
 // guildID   : The ID of a Guild.
 // enabled   : Whether the widget is enabled.
 // channelID : The widget Channel ID
-func (s *Session) GuildWidgetEdit(guildID string, enabled bool, channelID string) (st *GuildWidget, err error) {
+func (s *Session) GuildWidgetEdit(guildID string, enabled bool, channelID string, options ...RequestOption) (st *GuildWidget, err error) {
 	data := GuildWidget{enabled, channelID}
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuildWidget(guildID), data, EndpointGuildWidget(guildID))
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildWidget(guildID), data, EndpointGuildWidget(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1395,12 +1430,12 @@ func (s *Session) GuildWidgetEdit(guildID string, enabled bool, channelID string
 // GuildWidgetImage returns an image.Image for the Guild Widget image
 // guildID   : The ID of a Guild.
 // style     : Style options of the widget
-func (s *Session) GuildWidgetImage(guildID string, style string) (img image.Image, err error) {
+func (s *Session) GuildWidgetImage(guildID string, style string, options ...RequestOption) (img image.Image, err error) {
 	uri := EndpointGuildWidget(guildID)
 	if style != "" {
 		uri += "?style=" + style
 	}
-	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildWidget(guildID))
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildWidget(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1415,7 +1450,7 @@ func (s *Session) GuildWidgetImage(guildID string, style string) (img image.Imag
 // beforeID    : If provided all log entries returned will be before the given ID.
 // actionType  : If provided the log will be filtered for the given Action Type.
 // limit       : The number messages that can be returned. (default 50, min 1, max 100)
-func (s *Session) GuildAuditLog(guildID, userID, beforeID string, actionType, limit int) (st *GuildAuditLog, err error) {
+func (s *Session) GuildAuditLog(guildID, userID, beforeID string, actionType, limit int, options ...RequestOption) (st *GuildAuditLog, err error) {
 	uri := EndpointGuildAuditLogs(guildID)
 	v := url.Values{}
 	if userID != "" {
@@ -1434,7 +1469,7 @@ func (s *Session) GuildAuditLog(guildID, userID, beforeID string, actionType, li
 		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
 	}
 
-	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildAuditLogs(guildID))
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointGuildAuditLogs(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1445,8 +1480,8 @@ func (s *Session) GuildAuditLog(guildID, userID, beforeID string, actionType, li
 
 // GuildEmojis returns a list of Custom Emojis on a Guild
 // guildID   : The ID of a Guild
-func (s *Session) GuildEmojis(guildID string) (em []*Emoji, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildEmojis(guildID), nil, EndpointGuildEmojis(guildID))
+func (s *Session) GuildEmojis(guildID string, options ...RequestOption) (em []*Emoji, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildEmojis(guildID), nil, EndpointGuildEmojis(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1457,8 +1492,8 @@ func (s *Session) GuildEmojis(guildID string) (em []*Emoji, err error) {
 
 // GuildEmoji returns an Custom Emoji on a Guild
 // guildID   : The ID of a Guild
-func (s *Session) GuildEmoji(guildID string, emojiID string) (em *Emoji, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildEmoji(guildID, emojiID), nil, EndpointGuildEmoji(guildID, ""))
+func (s *Session) GuildEmoji(guildID string, emojiID string, options ...RequestOption) (em *Emoji, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildEmoji(guildID, emojiID), nil, EndpointGuildEmoji(guildID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1470,16 +1505,16 @@ func (s *Session) GuildEmoji(guildID string, emojiID string) (em *Emoji, err err
 // GuildEmojiCreate creates a new emoji
 // guildID : The ID of a Guild.
 // name    : The Name of the Emoji.
-// image   : The base64 encoded emoji image, has to be smaller than 256KB.
+// image   : The emoji image as a data URI, has to be smaller than 256KB, see ImageDataURI.
 // roles   : The roles for which this emoji will be whitelisted, can be nil.
-func (s *Session) GuildEmojiCreate(guildID, name, image string, roles []string) (emoji *Emoji, err error) {
+func (s *Session) GuildEmojiCreate(guildID, name, image string, roles []string, options ...RequestOption) (emoji *Emoji, err error) {
 	data := struct {
 		Name  string   `json:"name"`
 		Image string   `json:"image"`
 		Roles []string `json:"roles,omitempty"`
 	}{name, image, roles}
 
-	body, err := s.RequestWithBucketID("POST", EndpointGuildEmojis(guildID), data, EndpointGuildEmojis(guildID))
+	body, err := s.RequestWithBucketID("POST", EndpointGuildEmojis(guildID), data, EndpointGuildEmojis(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1493,13 +1528,13 @@ func (s *Session) GuildEmojiCreate(guildID, name, image string, roles []string)
 // emojiID : The ID of an Emoji.
 // name    : The Name of the Emoji.
 // roles   : The roles for which this emoji will be whitelisted, can be nil.
-func (s *Session) GuildEmojiEdit(guildID, emojiID, name string, roles []string) (emoji *Emoji, err error) {
+func (s *Session) GuildEmojiEdit(guildID, emojiID, name string, roles []string, options ...RequestOption) (emoji *Emoji, err error) {
 	data := struct {
 		Name  string   `json:"name"`
 		Roles []string `json:"roles,omitempty"`
 	}{name, roles}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointGuildEmoji(guildID, emojiID), data, EndpointGuildEmojis(guildID))
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildEmoji(guildID, emojiID), data, EndpointGuildEmojis(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -1511,8 +1546,8 @@ func (s *Session) GuildEmojiEdit(guildID, emojiID, name string, roles []string)
 // GuildEmojiDelete deletes an Emoji.
 // guildID : The ID of a Guild.
 // emojiID : The ID of an Emoji.
-func (s *Session) GuildEmojiDelete(guildID, emojiID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointGuildEmoji(guildID, emojiID), nil, EndpointGuildEmojis(guildID))
+func (s *Session) GuildEmojiDelete(guildID, emojiID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointGuildEmoji(guildID, emojiID), nil, EndpointGuildEmojis(guildID), options...)
 	return
 }
 
@@ -1522,8 +1557,8 @@ func (s *Session) GuildEmojiDelete(guildID, emojiID string) (err error) {
 
 // Channel returns a Channel structure of a specific Channel.
 // channelID  : The ID of the Channel you want returned.
-func (s *Session) Channel(channelID string) (st *Channel, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointChannel(channelID), nil, EndpointChannel(channelID))
+func (s *Session) Channel(channelID string, options ...RequestOption) (st *Channel, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointChannel(channelID), nil, EndpointChannel(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1535,17 +1570,17 @@ func (s *Session) Channel(channelID string) (st *Channel, err error) {
 // ChannelEdit edits the given channel
 // channelID  : The ID of a Channel
 // name       : The new name to assign the channel.
-func (s *Session) ChannelEdit(channelID, name string) (*Channel, error) {
+func (s *Session) ChannelEdit(channelID, name string, options ...RequestOption) (*Channel, error) {
 	return s.ChannelEditComplex(channelID, &ChannelEdit{
 		Name: name,
-	})
+	}, options...)
 }
 
 // ChannelEditComplex edits an existing channel, replacing the parameters entirely with ChannelEdit struct
 // channelID  : The ID of a Channel
 // data          : The channel struct to send
-func (s *Session) ChannelEditComplex(channelID string, data *ChannelEdit) (st *Channel, err error) {
-	body, err := s.RequestWithBucketID("PATCH", EndpointChannel(channelID), data, EndpointChannel(channelID))
+func (s *Session) ChannelEditComplex(channelID string, data *ChannelEdit, options ...RequestOption) (st *Channel, err error) {
+	body, err := s.RequestWithBucketID("PATCH", EndpointChannel(channelID), data, EndpointChannel(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1556,8 +1591,8 @@ func (s *Session) ChannelEditComplex(channelID string, data *ChannelEdit) (st *C
 
 // ChannelDelete deletes the given channel
 // channelID  : The ID of a Channel
-func (s *Session) ChannelDelete(channelID string) (st *Channel, err error) {
-	body, err := s.RequestWithBucketID("DELETE", EndpointChannel(channelID), nil, EndpointChannel(channelID))
+func (s *Session) ChannelDelete(channelID string, options ...RequestOption) (st *Channel, err error) {
+	body, err := s.RequestWithBucketID("DELETE", EndpointChannel(channelID), nil, EndpointChannel(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1569,8 +1604,8 @@ func (s *Session) ChannelDelete(channelID string) (st *Channel, err error) {
 // ChannelTyping broadcasts to all members that authenticated user is typing in
 // the given channel.
 // channelID  : The ID of a Channel
-func (s *Session) ChannelTyping(channelID string) (err error) {
-	_, err = s.RequestWithBucketID("POST", EndpointChannelTyping(channelID), nil, EndpointChannelTyping(channelID))
+func (s *Session) ChannelTyping(channelID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("POST", EndpointChannelTyping(channelID), nil, EndpointChannelTyping(channelID), options...)
 	return
 }
 
@@ -1581,7 +1616,7 @@ func (s *Session) ChannelTyping(channelID string) (err error) {
 // beforeID  : If provided all messages returned will be before given ID.
 // afterID   : If provided all messages returned will be after given ID.
 // aroundID  : If provided all messages returned will be around given ID.
-func (s *Session) ChannelMessages(channelID string, limit int, beforeID, afterID, aroundID string) (st []*Message, err error) {
+func (s *Session) ChannelMessages(channelID string, limit int, beforeID, afterID, aroundID string, options ...RequestOption) (st []*Message, err error) {
 	uri := EndpointChannelMessages(channelID)
 
 	v := url.Values{}
@@ -1601,7 +1636,7 @@ func (s *Session) ChannelMessages(channelID string, limit int, beforeID, afterID
 		uri += "?" + v.Encode()
 	}
 
-	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointChannelMessages(channelID))
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointChannelMessages(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1613,8 +1648,8 @@ func (s *Session) ChannelMessages(channelID string, limit int, beforeID, afterID
 // ChannelMessage gets a single message by ID from a given channel.
 // channeld  : The ID of a Channel
 // messageID : the ID of a Message
-func (s *Session) ChannelMessage(channelID, messageID string) (st *Message, err error) {
-	response, err := s.RequestWithBucketID("GET", EndpointChannelMessage(channelID, messageID), nil, EndpointChannelMessage(channelID, ""))
+func (s *Session) ChannelMessage(channelID, messageID string, options ...RequestOption) (st *Message, err error) {
+	response, err := s.RequestWithBucketID("GET", EndpointChannelMessage(channelID, messageID), nil, EndpointChannelMessage(channelID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1627,8 +1662,8 @@ func (s *Session) ChannelMessage(channelID, messageID string) (st *Message, err
 // channeld  : The ID of a Channel
 // messageID : the ID of a Message
 // lastToken : token returned by last ack
-func (s *Session) ChannelMessageAck(channelID, messageID, lastToken string) (st *Ack, err error) {
-	body, err := s.RequestWithBucketID("POST", EndpointChannelMessageAck(channelID, messageID), &Ack{Token: lastToken}, EndpointChannelMessageAck(channelID, ""))
+func (s *Session) ChannelMessageAck(channelID, messageID, lastToken string, options ...RequestOption) (st *Ack, err error) {
+	body, err := s.RequestWithBucketID("POST", EndpointChannelMessageAck(channelID, messageID), &Ack{Token: lastToken}, EndpointChannelMessageAck(channelID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1640,23 +1675,24 @@ func (s *Session) ChannelMessageAck(channelID, messageID, lastToken string) (st
 // ChannelMessageSend sends a message to the given channel.
 // channelID : The ID of a Channel.
 // content   : The message to send.
-func (s *Session) ChannelMessageSend(channelID string, content string) (*Message, error) {
+func (s *Session) ChannelMessageSend(channelID string, content string, options ...RequestOption) (*Message, error) {
 	return s.ChannelMessageSendComplex(channelID, &MessageSend{
 		Content: content,
-	})
+	}, options...)
 }
 
-var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
-
 // ChannelMessageSendComplex sends a message to the given channel.
 // channelID : The ID of a Channel.
 // data      : The message struct to send.
-func (s *Session) ChannelMessageSendComplex(channelID string, data *MessageSend) (st *Message, err error) {
+func (s *Session) ChannelMessageSendComplex(channelID string, data *MessageSend, options ...RequestOption) (st *Message, err error) {
 	for _, embed := range data.Embeds {
 		if embed != nil && embed.Type == "" {
 			embed.Type = "rich"
 		}
 	}
+	if err = ValidateEmbeds(data.Embeds); err != nil {
+		return
+	}
 
 	endpoint := EndpointChannelMessages(channelID)
 
@@ -1671,60 +1707,7 @@ func (s *Session) ChannelMessageSendComplex(channelID string, data *MessageSend)
 		}
 	}
 
-	var response []byte
-	if len(files) > 0 {
-		body := &bytes.Buffer{}
-		bodywriter := multipart.NewWriter(body)
-
-		var payload []byte
-		payload, err = json.Marshal(data)
-		if err != nil {
-			return
-		}
-
-		var p io.Writer
-
-		h := make(textproto.MIMEHeader)
-		h.Set("Content-Disposition", `form-data; name="payload_json"`)
-		h.Set("Content-Type", "application/json")
-
-		p, err = bodywriter.CreatePart(h)
-		if err != nil {
-			return
-		}
-
-		if _, err = p.Write(payload); err != nil {
-			return
-		}
-
-		for i, file := range files {
-			h := make(textproto.MIMEHeader)
-			h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file%d"; filename="%s"`, i, quoteEscaper.Replace(file.Name)))
-			contentType := file.ContentType
-			if contentType == "" {
-				contentType = "application/octet-stream"
-			}
-			h.Set("Content-Type", contentType)
-
-			p, err = bodywriter.CreatePart(h)
-			if err != nil {
-				return
-			}
-
-			if _, err = io.Copy(p, file.Reader); err != nil {
-				return
-			}
-		}
-
-		err = bodywriter.Close()
-		if err != nil {
-			return
-		}
-
-		response, err = s.request("POST", endpoint, bodywriter.FormDataContentType(), body.Bytes(), endpoint, 0)
-	} else {
-		response, err = s.RequestWithBucketID("POST", endpoint, data, endpoint)
-	}
+	response, err := s.requestWithFiles("POST", endpoint, channelID, data, files, endpoint, options...)
 	if err != nil {
 		return
 	}
@@ -1736,36 +1719,36 @@ func (s *Session) ChannelMessageSendComplex(channelID string, data *MessageSend)
 // ChannelMessageSendTTS sends a message to the given channel with Text to Speech.
 // channelID : The ID of a Channel.
 // content   : The message to send.
-func (s *Session) ChannelMessageSendTTS(channelID string, content string) (*Message, error) {
+func (s *Session) ChannelMessageSendTTS(channelID string, content string, options ...RequestOption) (*Message, error) {
 	return s.ChannelMessageSendComplex(channelID, &MessageSend{
 		Content: content,
 		Tts:     true,
-	})
+	}, options...)
 }
 
 // ChannelMessageSendEmbed sends a message to the given channel with embedded data.
 // channelID : The ID of a Channel.
 // embed     : The embed data to send.
-func (s *Session) ChannelMessageSendEmbed(channelID string, embed *MessageEmbed) (*Message, error) {
+func (s *Session) ChannelMessageSendEmbed(channelID string, embed *MessageEmbed, options ...RequestOption) (*Message, error) {
 	return s.ChannelMessageSendComplex(channelID, &MessageSend{
 		Embeds: []*MessageEmbed{embed},
-	})
+	}, options...)
 }
 
 // ChannelMessageSendEmbeds sends a message to the given channel with embedded data.
 // channelID : The ID of a Channel.
 // embeds    : The embed data to send.
-func (s *Session) ChannelMessageSendEmbeds(channelID string, embeds []*MessageEmbed) (*Message, error) {
+func (s *Session) ChannelMessageSendEmbeds(channelID string, embeds []*MessageEmbed, options ...RequestOption) (*Message, error) {
 	return s.ChannelMessageSendComplex(channelID, &MessageSend{
 		Embeds: embeds,
-	})
+	}, options...)
 }
 
 // ChannelMessageCrosspost crossposts a news channel message to the provided channel
 // channelID : The ID of a Channel
 // messageID : The ID of message in a news channel
-func (s *Session) ChannelMessageCrosspost(channelID, messageID string) (m *Message, err error) {
-	body, err := s.RequestWithBucketID("POST", EndpointChannelMessageCrosspost(channelID, messageID), nil, EndpointChannelMessageCrosspost(channelID, ""))
+func (s *Session) ChannelMessageCrosspost(channelID, messageID string, options ...RequestOption) (m *Message, err error) {
+	body, err := s.RequestWithBucketID("POST", EndpointChannelMessageCrosspost(channelID, messageID), nil, EndpointChannelMessageCrosspost(channelID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1779,20 +1762,23 @@ func (s *Session) ChannelMessageCrosspost(channelID, messageID string) (m *Messa
 // channelID  : The ID of a Channel
 // messageID  : The ID of a Message
 // content    : The contents of the message
-func (s *Session) ChannelMessageEdit(channelID, messageID, content string) (*Message, error) {
-	return s.ChannelMessageEditComplex(NewMessageEdit(channelID, messageID).SetContent(content))
+func (s *Session) ChannelMessageEdit(channelID, messageID, content string, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageEditComplex(NewMessageEdit(channelID, messageID).SetContent(content), options...)
 }
 
 // ChannelMessageEditComplex edits an existing message, replacing it entirely with
 // the given MessageEdit struct
-func (s *Session) ChannelMessageEditComplex(m *MessageEdit) (st *Message, err error) {
+func (s *Session) ChannelMessageEditComplex(m *MessageEdit, options ...RequestOption) (st *Message, err error) {
 	for _, embed := range m.Embeds {
 		if embed != nil && embed.Type == "" {
 			embed.Type = "rich"
 		}
 	}
+	if err = ValidateEmbeds(m.Embeds); err != nil {
+		return
+	}
 
-	response, err := s.RequestWithBucketID("PATCH", EndpointChannelMessage(m.Channel, m.ID), m, EndpointChannelMessage(m.Channel, ""))
+	response, err := s.requestWithFiles("PATCH", EndpointChannelMessage(m.Channel, m.ID), m.Channel, m, m.Files, EndpointChannelMessage(m.Channel, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1805,13 +1791,13 @@ func (s *Session) ChannelMessageEditComplex(m *MessageEdit) (st *Message, err er
 // channelID : The ID of a Channel
 // messageID : The ID of a Message
 // embeds    : The embed data to send
-func (s *Session) ChannelMessageEditEmbeds(channelID, messageID string, embeds []*MessageEmbed) (*Message, error) {
-	return s.ChannelMessageEditComplex(NewMessageEdit(channelID, messageID).SetEmbeds(embeds))
+func (s *Session) ChannelMessageEditEmbeds(channelID, messageID string, embeds []*MessageEmbed, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageEditComplex(NewMessageEdit(channelID, messageID).SetEmbeds(embeds), options...)
 }
 
 // ChannelMessageDelete deletes a message from the Channel.
-func (s *Session) ChannelMessageDelete(channelID, messageID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointChannelMessage(channelID, messageID), nil, EndpointChannelMessage(channelID, ""))
+func (s *Session) ChannelMessageDelete(channelID, messageID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointChannelMessage(channelID, messageID), nil, EndpointChannelMessage(channelID, ""), options...)
 	return
 }
 
@@ -1820,13 +1806,13 @@ func (s *Session) ChannelMessageDelete(channelID, messageID string) (err error)
 // If the slice is empty do nothing.
 // channelID : The ID of the channel for the messages to delete.
 // messages  : The IDs of the messages to be deleted. A slice of string IDs. A maximum of 100 messages.
-func (s *Session) ChannelMessagesBulkDelete(channelID string, messages []string) (err error) {
+func (s *Session) ChannelMessagesBulkDelete(channelID string, messages []string, options ...RequestOption) (err error) {
 	if len(messages) == 0 {
 		return
 	}
 
 	if len(messages) == 1 {
-		err = s.ChannelMessageDelete(channelID, messages[0])
+		err = s.ChannelMessageDelete(channelID, messages[0], options...)
 		return
 	}
 
@@ -1838,31 +1824,31 @@ func (s *Session) ChannelMessagesBulkDelete(channelID string, messages []string)
 		Messages []string `json:"messages"`
 	}{messages}
 
-	_, err = s.RequestWithBucketID("POST", EndpointChannelMessagesBulkDelete(channelID), data, EndpointChannelMessagesBulkDelete(channelID))
+	_, err = s.RequestWithBucketID("POST", EndpointChannelMessagesBulkDelete(channelID), data, EndpointChannelMessagesBulkDelete(channelID), options...)
 	return
 }
 
 // ChannelMessagePin pins a message within a given channel.
 // channelID: The ID of a channel.
 // messageID: The ID of a message.
-func (s *Session) ChannelMessagePin(channelID, messageID string) (err error) {
-	_, err = s.RequestWithBucketID("PUT", EndpointChannelMessagePin(channelID, messageID), nil, EndpointChannelMessagePin(channelID, ""))
+func (s *Session) ChannelMessagePin(channelID, messageID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("PUT", EndpointChannelMessagePin(channelID, messageID), nil, EndpointChannelMessagePin(channelID, ""), options...)
 	return
 }
 
 // ChannelMessageUnpin unpins a message within a given channel.
 // channelID: The ID of a channel.
 // messageID: The ID of a message.
-func (s *Session) ChannelMessageUnpin(channelID, messageID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointChannelMessagePin(channelID, messageID), nil, EndpointChannelMessagePin(channelID, ""))
+func (s *Session) ChannelMessageUnpin(channelID, messageID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointChannelMessagePin(channelID, messageID), nil, EndpointChannelMessagePin(channelID, ""), options...)
 	return
 }
 
 // ChannelMessagesPinned returns an array of Message structures for pinned messages
 // within a given channel
 // channelID : The ID of a Channel.
-func (s *Session) ChannelMessagesPinned(channelID string) (st []*Message, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointChannelMessagesPins(channelID), nil, EndpointChannelMessagesPins(channelID))
+func (s *Session) ChannelMessagesPinned(channelID string, options ...RequestOption) (st []*Message, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointChannelMessagesPins(channelID), nil, EndpointChannelMessagesPins(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1875,8 +1861,8 @@ func (s *Session) ChannelMessagesPinned(channelID string) (st []*Message, err er
 // channelID : The ID of a Channel.
 // name: The name of the file.
 // io.Reader : A reader for the file contents.
-func (s *Session) ChannelFileSend(channelID, name string, r io.Reader) (*Message, error) {
-	return s.ChannelMessageSendComplex(channelID, &MessageSend{File: &File{Name: name, Reader: r}})
+func (s *Session) ChannelFileSend(channelID, name string, r io.Reader, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageSendComplex(channelID, &MessageSend{File: &File{Name: name, Reader: r}}, options...)
 }
 
 // ChannelFileSendWithMessage sends a file to the given channel with an message.
@@ -1885,14 +1871,14 @@ func (s *Session) ChannelFileSend(channelID, name string, r io.Reader) (*Message
 // content: Optional Message content.
 // name: The name of the file.
 // io.Reader : A reader for the file contents.
-func (s *Session) ChannelFileSendWithMessage(channelID, content string, name string, r io.Reader) (*Message, error) {
-	return s.ChannelMessageSendComplex(channelID, &MessageSend{File: &File{Name: name, Reader: r}, Content: content})
+func (s *Session) ChannelFileSendWithMessage(channelID, content string, name string, r io.Reader, options ...RequestOption) (*Message, error) {
+	return s.ChannelMessageSendComplex(channelID, &MessageSend{File: &File{Name: name, Reader: r}, Content: content}, options...)
 }
 
 // ChannelInvites returns an array of Invite structures for the given channel
 // channelID   : The ID of a Channel
-func (s *Session) ChannelInvites(channelID string) (st []*Invite, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointChannelInvites(channelID), nil, EndpointChannelInvites(channelID))
+func (s *Session) ChannelInvites(channelID string, options ...RequestOption) (st []*Invite, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointChannelInvites(channelID), nil, EndpointChannelInvites(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1905,7 +1891,7 @@ func (s *Session) ChannelInvites(channelID string) (st []*Invite, err error) {
 // channelID   : The ID of a Channel
 // i           : An Invite struct with the values MaxAge, MaxUses and Temporary defined.
 // unique      : Does this invite have to be unique
-func (s *Session) ChannelInviteCreate(channelID string, i Invite, unique bool) (st *Invite, err error) {
+func (s *Session) ChannelInviteCreate(channelID string, i Invite, unique bool, options ...RequestOption) (st *Invite, err error) {
 	data := struct {
 		MaxAge    int  `json:"max_age"`
 		MaxUses   int  `json:"max_uses"`
@@ -1913,7 +1899,7 @@ func (s *Session) ChannelInviteCreate(channelID string, i Invite, unique bool) (
 		Unique    bool `json:"unique"`
 	}{i.MaxAge, i.MaxUses, i.Temporary, unique}
 
-	body, err := s.RequestWithBucketID("POST", EndpointChannelInvites(channelID), data, EndpointChannelInvites(channelID))
+	body, err := s.RequestWithBucketID("POST", EndpointChannelInvites(channelID), data, EndpointChannelInvites(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1925,33 +1911,33 @@ func (s *Session) ChannelInviteCreate(channelID string, i Invite, unique bool) (
 // ChannelPermissionSet creates a Permission Override for the given channel.
 // NOTE: This func name may changed.  Using Set instead of Create because
 // you can both create a new override or update an override with this function.
-func (s *Session) ChannelPermissionSet(channelID, targetID string, targetType PermissionOverwriteType, allow, deny int) (err error) {
+func (s *Session) ChannelPermissionSet(channelID, targetID string, targetType PermissionOverwriteType, allow, deny Permissions, options ...RequestOption) (err error) {
 	data := PermissionOverwrite{
 		Allow: allow,
 		Deny:  deny,
 		Type:  targetType,
 	}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointChannelPermission(channelID, targetID), data, EndpointChannelPermission(channelID, ""))
+	_, err = s.RequestWithBucketID("PUT", EndpointChannelPermission(channelID, targetID), data, EndpointChannelPermission(channelID, ""), options...)
 	return
 }
 
 // ChannelPermissionDelete deletes a specific permission override for the given channel.
 // NOTE: Name of this func may change.
-func (s *Session) ChannelPermissionDelete(channelID, targetID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointChannelPermission(channelID, targetID), nil, EndpointChannelPermission(channelID, ""))
+func (s *Session) ChannelPermissionDelete(channelID, targetID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointChannelPermission(channelID, targetID), nil, EndpointChannelPermission(channelID, ""), options...)
 	return
 }
 
 // ChannelFollow follow a New Channel to send message to target channel
 // channelID : ID of the channel to follow
 // targetID  : ID of the target channel
-func (s *Session) ChannelFollow(channelID, targetID string) (fc *FollowChannel, err error) {
+func (s *Session) ChannelFollow(channelID, targetID string, options ...RequestOption) (fc *FollowChannel, err error) {
 	data := struct {
 		WebHookChannelID string `json:"webhook_channel_id"`
 	}{targetID}
 
-	body, err := s.RequestWithBucketID("POST", EndpointChannelFollow(channelID), data, EndpointChannelFollow(channelID))
+	body, err := s.RequestWithBucketID("POST", EndpointChannelFollow(channelID), data, EndpointChannelFollow(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -1965,13 +1951,13 @@ func (s *Session) ChannelFollow(channelID, targetID string) (fc *FollowChannel,
 // userID      : User ID of the recipient
 // accessToken : Access Token of the recipient
 // nick        : Nickname of the recipient
-func (s *Session) ChannelDMRecipientAdd(channelID, userID, accessToken, nick string) (err error) {
+func (s *Session) ChannelDMRecipientAdd(channelID, userID, accessToken, nick string, options ...RequestOption) (err error) {
 	data := struct {
 		AccessToken string `json:"access_token"`
 		Nick        string `json:"nick"`
 	}{AccessToken: accessToken, Nick: nick}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointChannelDMRecipient(channelID, userID), data, EndpointChannelDMRecipient(channelID, ""))
+	_, err = s.RequestWithBucketID("PUT", EndpointChannelDMRecipient(channelID, userID), data, EndpointChannelDMRecipient(channelID, ""), options...)
 	if err != nil {
 		return
 	}
@@ -1982,12 +1968,56 @@ func (s *Session) ChannelDMRecipientAdd(channelID, userID, accessToken, nick str
 // ChannelDMRecipientRemove
 // channelID : ID of the DM channel
 // userID    : User ID of the recipient
-func (s *Session) ChannelDMRecipientRemove(channelID, userID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointChannelDMRecipient(channelID, userID), nil, EndpointChannelDMRecipient(channelID, ""))
+func (s *Session) ChannelDMRecipientRemove(channelID, userID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointChannelDMRecipient(channelID, userID), nil, EndpointChannelDMRecipient(channelID, ""), options...)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// ChannelThreadsActive returns all active threads of a channel.
+// channelID : The ID of a Channel.
+func (s *Session) ChannelThreadsActive(channelID string, options ...RequestOption) (st *ThreadsList, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointChannelThreadsActive(channelID), nil, EndpointChannelThreadsActive(channelID), options...)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// ChannelThreadsArchived returns archived threads of a channel, newest first.
+// channelID : The ID of a Channel.
+// private   : Whether to return private instead of public threads.
+// before    : If not zero, only threads archived before this time are returned.
+// limit     : The maximum number of threads to return, 0 for the default of Discord.
+func (s *Session) ChannelThreadsArchived(channelID string, private bool, before time.Time, limit int, options ...RequestOption) (st *ThreadsList, err error) {
+	uri := EndpointChannelThreadsArchivedPublic(channelID)
+	if private {
+		uri = EndpointChannelThreadsArchivedPrivate(channelID)
+	}
+	bucketID := uri
+
+	v := url.Values{}
+	if !before.IsZero() {
+		v.Set("before", before.UTC().Format(time.RFC3339))
+	}
+	if limit > 0 {
+		v.Set("limit", strconv.Itoa(limit))
+	}
+	if len(v) > 0 {
+		uri += "?" + v.Encode()
+	}
+
+	body, err := s.RequestWithBucketID("GET", uri, nil, bucketID, options...)
 	if err != nil {
 		return
 	}
 
+	err = unmarshal(body, &st)
 	return
 }
 
@@ -1997,8 +2027,8 @@ func (s *Session) ChannelDMRecipientRemove(channelID, userID string) (err error)
 
 // Invite returns an Invite structure of the given invite
 // inviteID : The invite code
-func (s *Session) Invite(inviteID string) (st *Invite, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointInvite(inviteID), nil, EndpointInvite(""))
+func (s *Session) Invite(inviteID string, options ...RequestOption) (st *Invite, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointInvite(inviteID), nil, EndpointInvite(""), options...)
 	if err != nil {
 		return
 	}
@@ -2009,8 +2039,8 @@ func (s *Session) Invite(inviteID string) (st *Invite, err error) {
 
 // InviteWithCounts returns an Invite structure of the given invite including approximate member counts
 // inviteID : The invite code
-func (s *Session) InviteWithCounts(inviteID string) (st *Invite, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointInvite(inviteID)+"?with_counts=true", nil, EndpointInvite(""))
+func (s *Session) InviteWithCounts(inviteID string, options ...RequestOption) (st *Invite, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointInvite(inviteID)+"?with_counts=true", nil, EndpointInvite(""), options...)
 	if err != nil {
 		return
 	}
@@ -2021,8 +2051,8 @@ func (s *Session) InviteWithCounts(inviteID string) (st *Invite, err error) {
 
 // InviteDelete deletes an existing invite
 // inviteID   : the code of an invite
-func (s *Session) InviteDelete(inviteID string) (st *Invite, err error) {
-	body, err := s.RequestWithBucketID("DELETE", EndpointInvite(inviteID), nil, EndpointInvite(""))
+func (s *Session) InviteDelete(inviteID string, options ...RequestOption) (st *Invite, err error) {
+	body, err := s.RequestWithBucketID("DELETE", EndpointInvite(inviteID), nil, EndpointInvite(""), options...)
 	if err != nil {
 		return
 	}
@@ -2033,8 +2063,8 @@ func (s *Session) InviteDelete(inviteID string) (st *Invite, err error) {
 
 // InviteAccept accepts an Invite to a Guild or Channel
 // inviteID : The invite code
-func (s *Session) InviteAccept(inviteID string) (st *Invite, err error) {
-	body, err := s.RequestWithBucketID("POST", EndpointInvite(inviteID), nil, EndpointInvite(""))
+func (s *Session) InviteAccept(inviteID string, options ...RequestOption) (st *Invite, err error) {
+	body, err := s.RequestWithBucketID("POST", EndpointInvite(inviteID), nil, EndpointInvite(""), options...)
 	if err != nil {
 		return
 	}
@@ -2048,8 +2078,8 @@ func (s *Session) InviteAccept(inviteID string) (st *Invite, err error) {
 // ------------------------------------------------------------------------------------------------
 
 // VoiceRegions returns the voice server regions
-func (s *Session) VoiceRegions() (st []*VoiceRegion, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointVoiceRegions, nil, EndpointVoiceRegions)
+func (s *Session) VoiceRegions(options ...RequestOption) (st []*VoiceRegion, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointVoiceRegions, nil, EndpointVoiceRegions, options...)
 	if err != nil {
 		return
 	}
@@ -2059,8 +2089,8 @@ func (s *Session) VoiceRegions() (st []*VoiceRegion, err error) {
 }
 
 // VoiceICE returns the voice server ICE information
-func (s *Session) VoiceICE() (st *VoiceICE, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointVoiceIce, nil, EndpointVoiceIce)
+func (s *Session) VoiceICE(options ...RequestOption) (st *VoiceICE, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointVoiceIce, nil, EndpointVoiceIce, options...)
 	if err != nil {
 		return
 	}
@@ -2074,8 +2104,8 @@ func (s *Session) VoiceICE() (st *VoiceICE, err error) {
 // ------------------------------------------------------------------------------------------------
 
 // Gateway returns the websocket Gateway address
-func (s *Session) Gateway() (gateway string, err error) {
-	response, err := s.RequestWithBucketID("GET", EndpointGateway, nil, EndpointGateway)
+func (s *Session) Gateway(options ...RequestOption) (gateway string, err error) {
+	response, err := s.RequestWithBucketID("GET", EndpointGateway, nil, EndpointGateway, options...)
 	if err != nil {
 		return
 	}
@@ -2100,8 +2130,8 @@ func (s *Session) Gateway() (gateway string, err error) {
 }
 
 // GatewayBot returns the websocket Gateway address and the recommended number of shards
-func (s *Session) GatewayBot() (st *GatewayBotResponse, err error) {
-	response, err := s.RequestWithBucketID("GET", EndpointGatewayBot, nil, EndpointGatewayBot)
+func (s *Session) GatewayBot(options ...RequestOption) (st *GatewayBotResponse, err error) {
+	response, err := s.RequestWithBucketID("GET", EndpointGatewayBot, nil, EndpointGatewayBot, options...)
 	if err != nil {
 		return
 	}
@@ -2124,14 +2154,14 @@ func (s *Session) GatewayBot() (st *GatewayBotResponse, err error) {
 // WebhookCreate returns a new Webhook.
 // channelID: The ID of a Channel.
 // name     : The name of the webhook.
-// avatar   : The avatar of the webhook.
-func (s *Session) WebhookCreate(channelID, name, avatar string) (st *Webhook, err error) {
+// avatar   : The avatar of the webhook as a data URI, see ImageDataURI.
+func (s *Session) WebhookCreate(channelID, name, avatar string, options ...RequestOption) (st *Webhook, err error) {
 	data := struct {
 		Name   string `json:"name"`
 		Avatar string `json:"avatar,omitempty"`
 	}{name, avatar}
 
-	body, err := s.RequestWithBucketID("POST", EndpointChannelWebhooks(channelID), data, EndpointChannelWebhooks(channelID))
+	body, err := s.RequestWithBucketID("POST", EndpointChannelWebhooks(channelID), data, EndpointChannelWebhooks(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -2142,8 +2172,8 @@ func (s *Session) WebhookCreate(channelID, name, avatar string) (st *Webhook, er
 
 // ChannelWebhooks returns all webhooks for a given channel.
 // channelID: The ID of a channel.
-func (s *Session) ChannelWebhooks(channelID string) (st []*Webhook, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointChannelWebhooks(channelID), nil, EndpointChannelWebhooks(channelID))
+func (s *Session) ChannelWebhooks(channelID string, options ...RequestOption) (st []*Webhook, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointChannelWebhooks(channelID), nil, EndpointChannelWebhooks(channelID), options...)
 	if err != nil {
 		return
 	}
@@ -2154,8 +2184,8 @@ func (s *Session) ChannelWebhooks(channelID string) (st []*Webhook, err error) {
 
 // GuildWebhooks returns all webhooks for a given guild.
 // guildID: The ID of a Guild.
-func (s *Session) GuildWebhooks(guildID string) (st []*Webhook, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointGuildWebhooks(guildID), nil, EndpointGuildWebhooks(guildID))
+func (s *Session) GuildWebhooks(guildID string, options ...RequestOption) (st []*Webhook, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildWebhooks(guildID), nil, EndpointGuildWebhooks(guildID), options...)
 	if err != nil {
 		return
 	}
@@ -2166,8 +2196,8 @@ func (s *Session) GuildWebhooks(guildID string) (st []*Webhook, err error) {
 
 // Webhook returns a webhook for a given ID
 // webhookID: The ID of a webhook.
-func (s *Session) Webhook(webhookID string) (st *Webhook, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointWebhook(webhookID), nil, EndpointWebhooks)
+func (s *Session) Webhook(webhookID string, options ...RequestOption) (st *Webhook, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointWebhook(webhookID), nil, EndpointWebhooks, options...)
 	if err != nil {
 		return
 	}
@@ -2179,8 +2209,8 @@ func (s *Session) Webhook(webhookID string) (st *Webhook, err error) {
 // WebhookWithToken returns a webhook for a given ID
 // webhookID: The ID of a webhook.
 // token    : The auth token for the webhook.
-func (s *Session) WebhookWithToken(webhookID, token string) (st *Webhook, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointWebhookToken(webhookID, token), nil, EndpointWebhookToken("", ""))
+func (s *Session) WebhookWithToken(webhookID, token string, options ...RequestOption) (st *Webhook, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointWebhookToken(webhookID, token), nil, EndpointWebhookToken("", ""), options...)
 	if err != nil {
 		return
 	}
@@ -2193,14 +2223,14 @@ func (s *Session) WebhookWithToken(webhookID, token string) (st *Webhook, err er
 // webhookID: The ID of a webhook.
 // name     : The name of the webhook.
 // avatar   : The avatar of the webhook.
-func (s *Session) WebhookEdit(webhookID, name, avatar, channelID string) (st *Webhook, err error) {
+func (s *Session) WebhookEdit(webhookID, name, avatar, channelID string, options ...RequestOption) (st *Webhook, err error) {
 	data := struct {
 		Name      string `json:"name,omitempty"`
 		Avatar    string `json:"avatar,omitempty"`
 		ChannelID string `json:"channel_id,omitempty"`
 	}{name, avatar, channelID}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointWebhook(webhookID), data, EndpointWebhooks)
+	body, err := s.RequestWithBucketID("PATCH", EndpointWebhook(webhookID), data, EndpointWebhooks, options...)
 	if err != nil {
 		return
 	}
@@ -2214,13 +2244,13 @@ func (s *Session) WebhookEdit(webhookID, name, avatar, channelID string) (st *We
 // token    : The auth token for the webhook.
 // name     : The name of the webhook.
 // avatar   : The avatar of the webhook.
-func (s *Session) WebhookEditWithToken(webhookID, token, name, avatar string) (st *Webhook, err error) {
+func (s *Session) WebhookEditWithToken(webhookID, token, name, avatar string, options ...RequestOption) (st *Webhook, err error) {
 	data := struct {
 		Name   string `json:"name,omitempty"`
 		Avatar string `json:"avatar,omitempty"`
 	}{name, avatar}
 
-	body, err := s.RequestWithBucketID("PATCH", EndpointWebhookToken(webhookID, token), data, EndpointWebhookToken("", ""))
+	body, err := s.RequestWithBucketID("PATCH", EndpointWebhookToken(webhookID, token), data, EndpointWebhookToken("", ""), options...)
 	if err != nil {
 		return
 	}
@@ -2231,16 +2261,16 @@ func (s *Session) WebhookEditWithToken(webhookID, token, name, avatar string) (s
 
 // WebhookDelete deletes a webhook for a given ID
 // webhookID: The ID of a webhook.
-func (s *Session) WebhookDelete(webhookID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointWebhook(webhookID), nil, EndpointWebhooks)
+func (s *Session) WebhookDelete(webhookID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointWebhook(webhookID), nil, EndpointWebhooks, options...)
 	return
 }
 
 // WebhookDeleteWithToken deletes a webhook for a given ID with an auth token.
 // webhookID: The ID of a webhook.
 // token    : The auth token for the webhook.
-func (s *Session) WebhookDeleteWithToken(webhookID, token string) (st *Webhook, err error) {
-	body, err := s.RequestWithBucketID("DELETE", EndpointWebhookToken(webhookID, token), nil, EndpointWebhookToken("", ""))
+func (s *Session) WebhookDeleteWithToken(webhookID, token string, options ...RequestOption) (st *Webhook, err error) {
+	body, err := s.RequestWithBucketID("DELETE", EndpointWebhookToken(webhookID, token), nil, EndpointWebhookToken("", ""), options...)
 	if err != nil {
 		return
 	}
@@ -2249,27 +2279,78 @@ func (s *Session) WebhookDeleteWithToken(webhookID, token string) (st *Webhook,
 	return
 }
 
+// webhookQuery returns the query string of webhook message requests.
+func webhookQuery(wait bool, threadID string) string {
+	query := url.Values{}
+	if wait {
+		query.Set("wait", "true")
+	}
+	if threadID != "" {
+		query.Set("thread_id", threadID)
+	}
+
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
+
 // WebhookExecute executes a webhook.
 // webhookID: The ID of a webhook.
 // token    : The auth token for the webhook
 // wait     : Waits for server confirmation of message send and ensures that the return struct is populated (it is nil otherwise)
-func (s *Session) WebhookExecute(webhookID, token string, wait bool, thread_id string, data *WebhookParams) (st *Message, err error) {
-	uri := EndpointWebhookToken(webhookID, token)
-	if wait || thread_id != "" {
-		uri += "?"
-		if wait {
-			uri += "wait=true"
-		}
-		if thread_id != "" {
-			if uri[len(uri)-1] == '?' {
-				uri += "thread_id="+thread_id
-			} else {
-				uri += "&thread_id="+thread_id
-			}
+// threadID : Sends the message to the thread with this ID in the webhook's channel, if not empty.
+func (s *Session) WebhookExecute(webhookID, token string, wait bool, threadID string, data *WebhookParams, options ...RequestOption) (st *Message, err error) {
+	return s.webhookExecute(webhookID, token, wait, threadID, data, data, options...)
+}
+
+// WebhookExecuteComplex executes a webhook with components and allowed mentions.
+// webhookID      : The ID of a webhook.
+// token          : The auth token for the webhook
+// wait           : Waits for server confirmation of message send and ensures that the return struct is populated (it is nil otherwise)
+// threadID       : Sends the message to the thread with this ID in the webhook's channel, if not empty.
+// components     : The components of the message, they need a webhook owned by an application.
+// allowedMentions: The mentions which are parsed, replacing data.AllowedMentions. If nil, Discord's defaults are used.
+func (s *Session) WebhookExecuteComplex(webhookID, token string, wait bool, threadID string, data *WebhookParams, components []Component, allowedMentions *AllowMention, options ...RequestOption) (st *Message, err error) {
+	payload := struct {
+		*WebhookParams
+		Components      []Component   `json:"components,omitempty"`
+		AllowedMentions *AllowMention `json:"allowed_mentions,omitempty"`
+	}{data, components, allowedMentions}
+
+	return s.webhookExecute(webhookID, token, wait, threadID, data, payload, options...)
+}
+
+// webhookExecute sends payload, the JSON body built from data, to a webhook.
+func (s *Session) webhookExecute(webhookID, token string, wait bool, threadID string, data *WebhookParams, payload interface{}, options ...RequestOption) (st *Message, err error) {
+	uri := EndpointWebhookToken(webhookID, token) + webhookQuery(wait, threadID)
+
+	var files []*File
+	if data != nil {
+		files = data.Files
+		if err = ValidateEmbeds(data.Embeds); err != nil {
+			return
 		}
 	}
 
-	response, err := s.RequestWithBucketID("POST", uri, data, EndpointWebhookToken("", ""))
+	response, err := s.requestWithFiles("POST", uri, "", payload, files, EndpointWebhookToken("", ""), options...)
+	if !wait || err != nil {
+		return
+	}
+
+	err = unmarshal(response, &st)
+	return
+}
+
+// WebhookExecuteSlack executes a webhook with a Slack compatible payload.
+// webhookID: The ID of a webhook.
+// token    : The auth token for the webhook
+// wait     : Waits for server confirmation of message send and ensures that the return struct is populated (it is nil otherwise)
+// threadID : Sends the message to the thread with this ID in the webhook's channel, if not empty.
+func (s *Session) WebhookExecuteSlack(webhookID, token string, wait bool, threadID string, data *SlackWebhookParams, options ...RequestOption) (st *Message, err error) {
+	uri := EndpointWebhookSlack(webhookID, token) + webhookQuery(wait, threadID)
+
+	response, err := s.RequestWithBucketID("POST", uri, data, EndpointWebhookToken("", ""), options...)
 	if !wait || err != nil {
 		return
 	}
@@ -2278,14 +2359,79 @@ func (s *Session) WebhookExecute(webhookID, token string, wait bool, thread_id s
 	return
 }
 
+// WebhookExecuteGitHub executes a webhook with a GitHub compatible payload.
+// Discord only posts messages for some GitHub events and ignores the others.
+// webhookID: The ID of a webhook.
+// token    : The auth token for the webhook
+// threadID : Sends the message to the thread with this ID in the webhook's channel, if not empty.
+// event    : The GitHub event name, as sent by GitHub in the X-GitHub-Event header, e.g. "push".
+// data     : A *GitHubWebhookPayload, or the payload as received from GitHub (e.g. a json.RawMessage).
+func (s *Session) WebhookExecuteGitHub(webhookID, token, threadID, event string, data interface{}, options ...RequestOption) (err error) {
+	uri := EndpointWebhookGitHub(webhookID, token) + webhookQuery(false, threadID)
+
+	options = append([]RequestOption{WithHeader("X-GitHub-Event", event)}, options...)
+	_, err = s.RequestWithBucketID("POST", uri, data, EndpointWebhookToken("", ""), options...)
+	return
+}
+
+// WebhookMessage returns a message previously sent by a webhook.
+// webhookID: The ID of a webhook.
+// token    : The auth token for the webhook
+// messageID: The ID of the message.
+// threadID : The ID of the thread the message is in, if any.
+func (s *Session) WebhookMessage(webhookID, token, messageID, threadID string, options ...RequestOption) (st *Message, err error) {
+	uri := EndpointWebhookMessage(webhookID, token, messageID) + webhookQuery(false, threadID)
+
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointWebhookToken("", ""), options...)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// WebhookMessageEdit edits a message previously sent by a webhook.
+// webhookID: The ID of a webhook.
+// token    : The auth token for the webhook
+// messageID: The ID of the message.
+// threadID : The ID of the thread the message is in, if any.
+// data     : The changes to the message, files are sent from data.Files.
+func (s *Session) WebhookMessageEdit(webhookID, token, messageID, threadID string, data *WebhookEdit, options ...RequestOption) (st *Message, err error) {
+	uri := EndpointWebhookMessage(webhookID, token, messageID) + webhookQuery(false, threadID)
+	if err = data.validateEmbeds(); err != nil {
+		return
+	}
+
+	body, err := s.requestWithFiles("PATCH", uri, "", data, data.Files, EndpointWebhookToken("", ""), options...)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// WebhookMessageDelete deletes a message previously sent by a webhook.
+// webhookID: The ID of a webhook.
+// token    : The auth token for the webhook
+// messageID: The ID of the message.
+// threadID : The ID of the thread the message is in, if any.
+func (s *Session) WebhookMessageDelete(webhookID, token, messageID, threadID string, options ...RequestOption) (err error) {
+	uri := EndpointWebhookMessage(webhookID, token, messageID) + webhookQuery(false, threadID)
+
+	_, err = s.RequestWithBucketID("DELETE", uri, nil, EndpointWebhookToken("", ""), options...)
+	return
+}
+
 // MessageReactionAdd creates an emoji reaction to a message.
 // channelID : The channel ID.
 // messageID : The message ID.
 // emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
-func (s *Session) MessageReactionAdd(channelID, messageID, emojiID string) error {
+func (s *Session) MessageReactionAdd(channelID, messageID, emojiID string, options ...RequestOption) error {
 	// emoji such as  #⃣ need to have # escaped
 	emojiID = strings.Replace(emojiID, "#", "%23", -1)
-	_, err := s.RequestWithBucketID("PUT", EndpointMessageReaction(channelID, messageID, emojiID, "@me"), nil, EndpointMessageReaction(channelID, "", "", ""))
+	_, err := s.RequestWithBucketID("PUT", EndpointMessageReaction(channelID, messageID, emojiID, "@me"), nil, EndpointMessageReaction(channelID, "", "", ""), options...)
 
 	return err
 }
@@ -2295,10 +2441,10 @@ func (s *Session) MessageReactionAdd(channelID, messageID, emojiID string) error
 // messageID : The message ID.
 // emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
 // userID	 : @me or ID of the user to delete the reaction for.
-func (s *Session) MessageReactionRemove(channelID, messageID, emojiID, userID string) error {
+func (s *Session) MessageReactionRemove(channelID, messageID, emojiID, userID string, options ...RequestOption) error {
 	// emoji such as  #⃣ need to have # escaped
 	emojiID = strings.Replace(emojiID, "#", "%23", -1)
-	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReaction(channelID, messageID, emojiID, userID), nil, EndpointMessageReaction(channelID, "", "", ""))
+	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReaction(channelID, messageID, emojiID, userID), nil, EndpointMessageReaction(channelID, "", "", ""), options...)
 
 	return err
 }
@@ -2306,8 +2452,8 @@ func (s *Session) MessageReactionRemove(channelID, messageID, emojiID, userID st
 // MessageReactionsRemoveAll deletes all reactions from a message
 // channelID : The channel ID
 // messageID : The message ID.
-func (s *Session) MessageReactionsRemoveAll(channelID, messageID string) error {
-	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReactionsAll(channelID, messageID), nil, EndpointMessageReactionsAll(channelID, messageID))
+func (s *Session) MessageReactionsRemoveAll(channelID, messageID string, options ...RequestOption) error {
+	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReactionsAll(channelID, messageID), nil, EndpointMessageReactionsAll(channelID, messageID), options...)
 
 	return err
 }
@@ -2316,8 +2462,8 @@ func (s *Session) MessageReactionsRemoveAll(channelID, messageID string) error {
 // channelID : The channel ID
 // messageID : The message ID.
 // emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
-func (s *Session) MessageReactionsRemoveEmoji(channelID, messageID, emojiID string) error {
-	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReactions(channelID, messageID, emojiID), nil, EndpointMessageReactions(channelID, messageID, ""))
+func (s *Session) MessageReactionsRemoveEmoji(channelID, messageID, emojiID string, options ...RequestOption) error {
+	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReactions(channelID, messageID, emojiID), nil, EndpointMessageReactions(channelID, messageID, ""), options...)
 
 	return err
 }
@@ -2327,7 +2473,12 @@ func (s *Session) MessageReactionsRemoveEmoji(channelID, messageID, emojiID stri
 // messageID : The message ID.
 // emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
 // limit    : max number of users to return (max 100)
-func (s *Session) MessageReactions(channelID, messageID, emojiID string, limit int) (st []*User, err error) {
+func (s *Session) MessageReactions(channelID, messageID, emojiID string, limit int, options ...RequestOption) (st []*User, err error) {
+	return s.messageReactions(channelID, messageID, emojiID, limit, "", options...)
+}
+
+// messageReactions returns a page of the users who reacted with an emoji, starting after afterID.
+func (s *Session) messageReactions(channelID, messageID, emojiID string, limit int, afterID string, options ...RequestOption) (st []*User, err error) {
 	// emoji such as  #⃣ need to have # escaped
 	emojiID = strings.Replace(emojiID, "#", "%23", -1)
 	uri := EndpointMessageReactions(channelID, messageID, emojiID)
@@ -2337,12 +2488,49 @@ func (s *Session) MessageReactions(channelID, messageID, emojiID string, limit i
 	if limit > 0 {
 		v.Set("limit", strconv.Itoa(limit))
 	}
+	if afterID != "" {
+		v.Set("after", afterID)
+	}
 
 	if len(v) > 0 {
 		uri += "?" + v.Encode()
 	}
 
-	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointMessageReaction(channelID, "", "", ""))
+	body, err := s.RequestWithBucketID("GET", uri, nil, EndpointMessageReaction(channelID, "", "", ""), options...)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// ------------------------------------------------------------------------------------------------
+// Functions specific to Interactions
+// ------------------------------------------------------------------------------------------------
+
+// InteractionRespond creates the response to an interaction.
+// interaction : The interaction to respond to.
+// resp        : The response data, files are sent from resp.Data.Files.
+func (s *Session) InteractionRespond(interaction *Interaction, resp *InteractionResponse, options ...RequestOption) (err error) {
+	var files []*File
+	if resp.Data != nil {
+		files = resp.Data.Files
+		if err = validateEmbedValues(resp.Data.Embeds); err != nil {
+			return
+		}
+	}
+
+	endpoint := EndpointInteractionResponse(interaction.ID, interaction.Token)
+	_, err = s.requestWithFiles("POST", endpoint, "", resp, files, EndpointInteractionResponse("", ""), options...)
+	return
+}
+
+// InteractionResponse returns the original response message of an interaction.
+// interaction : The interaction whose response to return.
+func (s *Session) InteractionResponse(interaction *Interaction, options ...RequestOption) (st *Message, err error) {
+	endpoint := EndpointInteractionOriginal(interaction.ApplicationID, interaction.Token)
+	body, err := s.RequestWithBucketID("GET", endpoint, nil, EndpointInteractionOriginal("", ""), options...)
 	if err != nil {
 		return
 	}
@@ -2351,17 +2539,93 @@ func (s *Session) MessageReactions(channelID, messageID, emojiID string, limit i
 	return
 }
 
+// InteractionResponseEdit edits the original response to an interaction.
+// interaction : The interaction whose response to edit.
+// newresp     : The changes to the response.
+func (s *Session) InteractionResponseEdit(interaction *Interaction, newresp *WebhookEdit, options ...RequestOption) (st *Message, err error) {
+	endpoint := EndpointInteractionOriginal(interaction.ApplicationID, interaction.Token)
+	if err = newresp.validateEmbeds(); err != nil {
+		return
+	}
+
+	body, err := s.requestWithFiles("PATCH", endpoint, "", newresp, newresp.Files, EndpointInteractionOriginal("", ""), options...)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// InteractionResponseDelete deletes the original response to an interaction.
+// interaction : The interaction whose response to delete.
+func (s *Session) InteractionResponseDelete(interaction *Interaction, options ...RequestOption) (err error) {
+	endpoint := EndpointInteractionOriginal(interaction.ApplicationID, interaction.Token)
+	_, err = s.RequestWithBucketID("DELETE", endpoint, nil, EndpointInteractionOriginal("", ""), options...)
+	return
+}
+
+// FollowupMessageCreate creates a followup message for an interaction.
+// interaction : The interaction to follow up.
+// wait        : Waits for server confirmation of message send and ensures that the return struct is populated (it is nil otherwise)
+// data        : The message data, files are sent from data.Files.
+func (s *Session) FollowupMessageCreate(interaction *Interaction, wait bool, data *WebhookParams, options ...RequestOption) (st *Message, err error) {
+	uri := EndpointInteractionFollowup(interaction.ApplicationID, interaction.Token)
+	if wait {
+		uri += "?wait=true"
+	}
+	if err = ValidateEmbeds(data.Embeds); err != nil {
+		return
+	}
+
+	response, err := s.requestWithFiles("POST", uri, "", data, data.Files, EndpointInteractionFollowup("", ""), options...)
+	if !wait || err != nil {
+		return
+	}
+
+	err = unmarshal(response, &st)
+	return
+}
+
+// FollowupMessageEdit edits a followup message of an interaction.
+// interaction : The interaction the followup message belongs to.
+// messageID   : The ID of the followup message.
+// data        : The changes to the message.
+func (s *Session) FollowupMessageEdit(interaction *Interaction, messageID string, data *WebhookEdit, options ...RequestOption) (st *Message, err error) {
+	endpoint := EndpointInteractionFollowupMessage(interaction.ApplicationID, interaction.Token, messageID)
+	if err = data.validateEmbeds(); err != nil {
+		return
+	}
+
+	body, err := s.requestWithFiles("PATCH", endpoint, "", data, data.Files, EndpointInteractionFollowupMessage("", "", ""), options...)
+	if err != nil {
+		return
+	}
+
+	err = unmarshal(body, &st)
+	return
+}
+
+// FollowupMessageDelete deletes a followup message of an interaction.
+// interaction : The interaction the followup message belongs to.
+// messageID   : The ID of the followup message.
+func (s *Session) FollowupMessageDelete(interaction *Interaction, messageID string, options ...RequestOption) (err error) {
+	endpoint := EndpointInteractionFollowupMessage(interaction.ApplicationID, interaction.Token, messageID)
+	_, err = s.RequestWithBucketID("DELETE", endpoint, nil, EndpointInteractionFollowupMessage("", "", ""), options...)
+	return
+}
+
 // ------------------------------------------------------------------------------------------------
 // Functions specific to user notes
 // ------------------------------------------------------------------------------------------------
 
 // UserNoteSet sets the note for a specific user.
-func (s *Session) UserNoteSet(userID string, message string) (err error) {
+func (s *Session) UserNoteSet(userID string, message string, options ...RequestOption) (err error) {
 	data := struct {
 		Note string `json:"note"`
 	}{message}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointUserNotes(userID), data, EndpointUserNotes(""))
+	_, err = s.RequestWithBucketID("PUT", EndpointUserNotes(userID), data, EndpointUserNotes(""), options...)
 	return
 }
 
@@ -2370,8 +2634,8 @@ func (s *Session) UserNoteSet(userID string, message string) (err error) {
 // ------------------------------------------------------------------------------------------------
 
 // RelationshipsGet returns an array of all the relationships of the user.
-func (s *Session) RelationshipsGet() (r []*Relationship, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointRelationships(), nil, EndpointRelationships())
+func (s *Session) RelationshipsGet(options ...RequestOption) (r []*Relationship, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointRelationships(), nil, EndpointRelationships(), options...)
 	if err != nil {
 		return
 	}
@@ -2382,47 +2646,47 @@ func (s *Session) RelationshipsGet() (r []*Relationship, err error) {
 
 // relationshipCreate creates a new relationship. (I.e. send or accept a friend request, block a user.)
 // relationshipType : 1 = friend, 2 = blocked, 3 = incoming friend req, 4 = sent friend req
-func (s *Session) relationshipCreate(userID string, relationshipType int) (err error) {
+func (s *Session) relationshipCreate(userID string, relationshipType int, options ...RequestOption) (err error) {
 	data := struct {
 		Type int `json:"type"`
 	}{relationshipType}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointRelationship(userID), data, EndpointRelationships())
+	_, err = s.RequestWithBucketID("PUT", EndpointRelationship(userID), data, EndpointRelationships(), options...)
 	return
 }
 
 // RelationshipFriendRequestSend sends a friend request to a user.
 // userID: ID of the user.
-func (s *Session) RelationshipFriendRequestSend(userID string) (err error) {
-	err = s.relationshipCreate(userID, 4)
+func (s *Session) RelationshipFriendRequestSend(userID string, options ...RequestOption) (err error) {
+	err = s.relationshipCreate(userID, 4, options...)
 	return
 }
 
 // RelationshipFriendRequestAccept accepts a friend request from a user.
 // userID: ID of the user.
-func (s *Session) RelationshipFriendRequestAccept(userID string) (err error) {
-	err = s.relationshipCreate(userID, 1)
+func (s *Session) RelationshipFriendRequestAccept(userID string, options ...RequestOption) (err error) {
+	err = s.relationshipCreate(userID, 1, options...)
 	return
 }
 
 // RelationshipUserBlock blocks a user.
 // userID: ID of the user.
-func (s *Session) RelationshipUserBlock(userID string) (err error) {
-	err = s.relationshipCreate(userID, 2)
+func (s *Session) RelationshipUserBlock(userID string, options ...RequestOption) (err error) {
+	err = s.relationshipCreate(userID, 2, options...)
 	return
 }
 
 // RelationshipDelete removes the relationship with a user.
 // userID: ID of the user.
-func (s *Session) RelationshipDelete(userID string) (err error) {
-	_, err = s.RequestWithBucketID("DELETE", EndpointRelationship(userID), nil, EndpointRelationships())
+func (s *Session) RelationshipDelete(userID string, options ...RequestOption) (err error) {
+	_, err = s.RequestWithBucketID("DELETE", EndpointRelationship(userID), nil, EndpointRelationships(), options...)
 	return
 }
 
 // RelationshipsMutualGet returns an array of all the users both @me and the given user is friends with.
 // userID: ID of the user.
-func (s *Session) RelationshipsMutualGet(userID string) (mf []*User, err error) {
-	body, err := s.RequestWithBucketID("GET", EndpointRelationshipsMutual(userID), nil, EndpointRelationshipsMutual(userID))
+func (s *Session) RelationshipsMutualGet(userID string, options ...RequestOption) (mf []*User, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointRelationshipsMutual(userID), nil, EndpointRelationshipsMutual(userID), options...)
 	if err != nil {
 		return
 	}
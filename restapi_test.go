@@ -1,8 +1,8 @@
 package discordgo
 
 import (
-	"encoding/json"
-	"log"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -298,10 +298,21 @@ func TestGuildPrune(t *testing.T) {
 }
 */
 
-func TestEndpointCounting(t *testing.T) {
-	b, err := json.MarshalIndent(RequestsPerEndpoint, "", "  ")
-	if err != nil {
-		t.Errorf("Error while serialization to josn: %s", err)
+func TestWithAuditLogReason(t *testing.T) {
+	var header string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Audit-Log-Reason")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := newRetryTestSession(NewRetryPolicy())
+	urlStr := srv.URL + "/api/v8/guilds/81384788765712384/members/81384788765712385"
+	if _, err := s.RequestWithBucketID("DELETE", urlStr, nil, urlStr, WithAuditLogReason("spam & ads + more; ok")); err != nil {
+		t.Fatalf("request returned error: %v", err)
+	}
+
+	if header != "spam%20%26%20ads%20%2B%20more%3B%20ok" {
+		t.Errorf("unexpected X-Audit-Log-Reason header %q", header)
 	}
-	log.Println(string(b))
 }
@@ -0,0 +1,216 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the retry policy used by the REST API functions.
+
+package discordgo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// A RetryPolicy decides which failed REST requests are retried and how long
+// to wait between attempts. The number of attempts is capped by
+// Session.MaxRestRetries.
+type RetryPolicy struct {
+	// HTTP status codes which should be retried.
+	StatusCodes []int
+
+	// Methods which may be retried. Requests using any other method are
+	// never retried, as they might not be safe to send twice.
+	// If empty, all methods may be retried.
+	Methods []string
+
+	// RetryError reports whether a transport error (connection resets,
+	// timeouts, ...) should be retried. If nil, transport errors are never retried.
+	RetryError func(err error) bool
+
+	// BaseDelay is the delay before the first retry, it is doubled for
+	// every following attempt up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Jitter is the fraction (0-1) of the delay which is randomized
+	// to avoid many clients retrying at the same time.
+	Jitter float64
+
+	// OnRetry, if set, is called before every retry.
+	// A RequestRetry event is dispatched as well.
+	OnRetry func(*RequestRetry)
+}
+
+// NewRetryPolicy returns the default RetryPolicy. It retries HTTP 500, 502,
+// 503 and 504 responses as well as temporary transport errors, but only for
+// idempotent methods.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		StatusCodes: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		Methods:    []string{"GET", "HEAD", "OPTIONS", "PUT", "DELETE"},
+		RetryError: IsTemporaryError,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   10 * time.Second,
+		Jitter:     0.5,
+	}
+}
+
+// ShouldRetry reports whether a request should be retried.
+// method     : The HTTP method of the request.
+// statusCode : The HTTP status code of the response, 0 if there was none.
+// err        : The transport error, nil if a response was received.
+func (p *RetryPolicy) ShouldRetry(method string, statusCode int, err error) bool {
+	if p == nil {
+		return false
+	}
+
+	if len(p.Methods) > 0 {
+		allowed := false
+		for _, m := range p.Methods {
+			if strings.EqualFold(m, method) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+
+	if err != nil {
+		return p.RetryError != nil && p.RetryError(err)
+	}
+
+	for _, code := range p.StatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	retryRandMu sync.Mutex
+	retryRand   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Backoff returns the delay before the given retry attempt, starting at 0.
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	if p == nil || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			delay = p.MaxDelay
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+
+		spread := int64(float64(delay) * jitter)
+		if spread > 0 {
+			retryRandMu.Lock()
+			n := retryRand.Int63n(spread)
+			retryRandMu.Unlock()
+
+			delay = delay - time.Duration(spread) + time.Duration(n)
+		}
+	}
+
+	return delay
+}
+
+// IsTemporaryError reports whether err is a transport error which is likely
+// to go away when the request is sent again, such as a timeout or a reset connection.
+func IsTemporaryError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// An expired deadline or a cancellation of the caller isn't temporary,
+	// even though it's reported as a timeout.
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return false
+}
+
+// retryPolicy returns the retry policy of the session, falling back to the
+// default policy when none is set.
+func (s *Session) retryPolicy() *RetryPolicy {
+	if s.RetryPolicy != nil {
+		return s.RetryPolicy
+	}
+	return defaultRetryPolicy
+}
+
+var defaultRetryPolicy = NewRetryPolicy()
+
+// waitRetry notifies listeners about an upcoming retry and sleeps for the backoff duration.
+func (s *Session) waitRetry(method, urlStr string, statusCode int, err error, attempt int) {
+	policy := s.retryPolicy()
+
+	retry := &RequestRetry{
+		Method:     method,
+		URL:        urlStr,
+		Attempt:    attempt + 1,
+		StatusCode: statusCode,
+		Err:        err,
+		Delay:      policy.Backoff(attempt),
+	}
+
+	if err != nil {
+		s.log(LogInformational, "%s %s failed (%s), retrying in %s", method, urlStr, err, retry.Delay)
+	} else {
+		s.log(LogInformational, "%s %s failed (HTTP %d), retrying in %s", method, urlStr, statusCode, retry.Delay)
+	}
+
+	if policy.OnRetry != nil {
+		policy.OnRetry(retry)
+	}
+	s.handleEvent(requestRetryEventType, retry)
+
+	time.Sleep(retry.Delay)
+}
@@ -0,0 +1,138 @@
+package discordgo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestSession(policy *RetryPolicy) *Session {
+	return &Session{
+		Ratelimiter:    NewRatelimiter(),
+		Client:         &http.Client{Timeout: 5 * time.Second},
+		MaxRestRetries: 3,
+		RetryPolicy:    policy,
+	}
+}
+
+func TestRequestRetryStatus(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	policy := NewRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+
+	var retries int32
+	policy.OnRetry = func(r *RequestRetry) {
+		atomic.AddInt32(&retries, 1)
+		if r.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("unexpected status code in retry: %d", r.StatusCode)
+		}
+	}
+
+	s := newRetryTestSession(policy)
+	if _, err := s.RequestWithBucketID("GET", srv.URL+"/api/v8/channels/retry", nil, srv.URL+"/api/v8/channels/retry"); err != nil {
+		t.Fatalf("request returned error: %v", err)
+	}
+
+	if atomic.LoadInt32(&retries) != 2 {
+		t.Errorf("expected 2 retries, got %d", retries)
+	}
+}
+
+func TestRequestRetryNonIdempotent(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	policy := NewRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+
+	s := newRetryTestSession(policy)
+	_, err := s.RequestWithBucketID("POST", srv.URL+"/api/v8/channels/retry", nil, srv.URL+"/api/v8/channels/retry")
+	if _, ok := err.(*RESTError); !ok {
+		t.Fatalf("expected a RESTError, got %v", err)
+	}
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected POST to be sent once, got %d", calls)
+	}
+}
+
+func TestRequestRetryExhausted(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	policy := NewRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+
+	s := newRetryTestSession(policy)
+	s.MaxRestRetries = 2
+	_, err := s.RequestWithBucketID("GET", srv.URL+"/api/v8/channels/retry", nil, srv.URL+"/api/v8/channels/retry")
+	if restErr, ok := err.(*RESTError); !ok || restErr.Response.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected a RESTError, got %v", err)
+	}
+
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+		Jitter:    0.5,
+	}
+
+	for attempt, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		d := policy.Backoff(attempt)
+		if d > max || d < max/2 {
+			t.Errorf("Backoff(%d) = %s, expected between %s and %s", attempt, d, max/2, max)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsTemporaryError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&url.Error{Op: "Get", URL: "https://discord.com", Err: timeoutError{}}, true},
+		{&url.Error{Op: "Get", URL: "https://discord.com", Err: io.ErrUnexpectedEOF}, true},
+		{&url.Error{Op: "Get", URL: "https://discord.com", Err: context.DeadlineExceeded}, false},
+		{&url.Error{Op: "Get", URL: "https://discord.com", Err: context.Canceled}, false},
+		{errors.New("other"), false},
+	}
+	for _, tt := range tests {
+		if got := IsTemporaryError(tt.err); got != tt.want {
+			t.Errorf("IsTemporaryError(%v) = %v, expected %v", tt.err, got, tt.want)
+		}
+	}
+}
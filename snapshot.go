@@ -0,0 +1,206 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains snapshots of the state, which let a restarted session
+// resume the gateway session with a warm cache instead of waiting for every
+// guild to be sent again.
+
+package discordgo
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// snapshotVersion is the version of the snapshot format, snapshots of other
+// versions are rejected.
+const snapshotVersion = 1
+
+// Errors returned when restoring a snapshot fails.
+var (
+	ErrSnapshotVersion = errors.New("unsupported snapshot version")
+	ErrSnapshotStale   = errors.New("snapshot is stale")
+	ErrSnapshotStorage = errors.New("snapshots require the in-memory state storage")
+)
+
+// stateSnapshot is the content of a snapshot, stored as gzipped JSON.
+type stateSnapshot struct {
+	Version    int       `json:"version"`
+	APIVersion string    `json:"api_version"`
+	CreatedAt  time.Time `json:"created_at"`
+	Sequence   int64     `json:"sequence"`
+	Ready      *Ready    `json:"ready"`
+
+	// Messages are the cached messages by channel ID, as they aren't part of
+	// the JSON of channels.
+	Messages map[string][]*Message `json:"messages,omitempty"`
+}
+
+// WriteSnapshot writes a snapshot of the state to w. The snapshot covers the
+// session ID, guilds, channels, roles, members, emojis, presences, voice
+// states and cached messages, including those of the MessageCache. States
+// created with NewStateWithStorage can't be snapshotted, their storage has to
+// persist itself.
+// sequence : The sequence of the gateway session, stored for resuming it.
+func (s *State) WriteSnapshot(w io.Writer, sequence int64) error {
+	if s == nil {
+		return ErrNilState
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	if _, ok := s.storage.(*memoryStateStorage); !ok {
+		return ErrSnapshotStorage
+	}
+
+	ready := s.Ready
+	snapshot := stateSnapshot{
+		Version:    snapshotVersion,
+		APIVersion: APIVersion,
+		CreatedAt:  time.Now().UTC(),
+		Sequence:   sequence,
+		Ready:      &ready,
+		Messages:   make(map[string][]*Message),
+	}
+
+	addMessages := func(channels []*Channel) {
+		for _, c := range channels {
+			messages := c.Messages
+			if s.MessageCache != nil {
+				messages = s.MessageCache.Messages(c.ID)
+			}
+			if len(messages) > 0 {
+				snapshot.Messages[c.ID] = messages
+			}
+		}
+	}
+	addMessages(ready.PrivateChannels)
+	for _, g := range ready.Guilds {
+		addMessages(g.Channels)
+	}
+
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(&snapshot); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// ReadSnapshot replaces the content of the state with a snapshot written by
+// WriteSnapshot and returns the sequence stored with it. Snapshots of another
+// version of the format or of the API are rejected with ErrSnapshotVersion.
+// maxAge : The maximum age of the snapshot, or 0 for any age. Older snapshots are rejected with ErrSnapshotStale.
+func (s *State) ReadSnapshot(r io.Reader, maxAge time.Duration) (sequence int64, err error) {
+	if s == nil {
+		return 0, ErrNilState
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("reading snapshot: %w", err)
+	}
+	defer zr.Close()
+
+	var snapshot stateSnapshot
+	if err = json.NewDecoder(zr).Decode(&snapshot); err != nil {
+		return 0, fmt.Errorf("reading snapshot: %w", err)
+	}
+
+	if snapshot.Version != snapshotVersion {
+		return 0, fmt.Errorf("%w: %d, expected %d", ErrSnapshotVersion, snapshot.Version, snapshotVersion)
+	}
+	if snapshot.APIVersion != APIVersion {
+		return 0, fmt.Errorf("%w: API version %s, expected %s", ErrSnapshotVersion, snapshot.APIVersion, APIVersion)
+	}
+	if age := time.Since(snapshot.CreatedAt); maxAge > 0 && age > maxAge {
+		return 0, fmt.Errorf("%w: created %s ago", ErrSnapshotStale, age.Round(time.Second))
+	}
+	if snapshot.Ready == nil {
+		return 0, errors.New("reading snapshot: missing ready")
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.storage.(*memoryStateStorage); !ok {
+		return 0, ErrSnapshotStorage
+	}
+
+	ready := snapshot.Ready
+	setMessages := func(channels []*Channel) {
+		for _, c := range channels {
+			if s.MessageCache == nil {
+				c.Messages = snapshot.Messages[c.ID]
+				continue
+			}
+			for _, m := range snapshot.Messages[c.ID] {
+				s.MessageCache.Add(m)
+			}
+		}
+	}
+	setMessages(ready.PrivateChannels)
+	for _, g := range ready.Guilds {
+		setMessages(g.Channels)
+	}
+
+	s.Ready = *ready
+	s.storage = newMemoryStateStorage(&s.Ready)
+	if err = s.storage.Load(&s.Ready); err != nil {
+		return 0, err
+	}
+
+	return snapshot.Sequence, nil
+}
+
+// SaveSnapshot writes a snapshot of the state and the gateway session to w,
+// e.g. before the process exits. The session has to be closed first, so no
+// events arrive after the sequence which is stored. Close it with a code
+// which keeps the gateway session for resuming it:
+//
+//	s.CloseWithCode(websocket.CloseServiceRestart)
+//	f, _ := os.Create("state.snapshot")
+//	err := s.SaveSnapshot(f)
+func (s *Session) SaveSnapshot(w io.Writer) error {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.wsConn != nil {
+		return ErrWSAlreadyOpen
+	}
+
+	return s.State.WriteSnapshot(w, atomic.LoadInt64(s.sequence))
+}
+
+// RestoreSnapshot restores the state and the gateway session from a snapshot
+// written by SaveSnapshot. It has to be called before Open, which then
+// resumes the gateway session. If Discord rejects the resume, the session
+// identifies again and the state is replaced by the new Ready.
+// maxAge : The maximum age of the snapshot, see State.ReadSnapshot.
+func (s *Session) RestoreSnapshot(r io.Reader, maxAge time.Duration) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.wsConn != nil {
+		return ErrWSAlreadyOpen
+	}
+
+	sequence, err := s.State.ReadSnapshot(r, maxAge)
+	if err != nil {
+		return err
+	}
+
+	s.sessionID = s.State.SessionID
+	atomic.StoreInt64(s.sequence, sequence)
+
+	return nil
+}
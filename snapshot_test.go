@@ -0,0 +1,125 @@
+package discordgo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestSnapshot(t *testing.T) {
+	src := &Session{State: NewState(), StateEnabled: true, sequence: new(int64)}
+	src.State.MaxMessageCount = 10
+	ready := stateTestReady()
+	ready.SessionID = "session"
+	if err := src.State.OnInterface(src, ready); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.State.MessageAdd(&Message{ID: "10", ChannelID: "2", Content: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	*src.sequence = 42
+
+	var buf bytes.Buffer
+	if err := src.SaveSnapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := &Session{State: NewState(), sequence: new(int64)}
+	if err := dst.RestoreSnapshot(bytes.NewReader(buf.Bytes()), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	if dst.sessionID != "session" || *dst.sequence != 42 {
+		t.Errorf("unexpected session %q, sequence %d", dst.sessionID, *dst.sequence)
+	}
+	if m, err := dst.State.Member("1", "3"); err != nil || m.JoinedAt == "" {
+		t.Errorf("unexpected member %+v, %v", m, err)
+	}
+	if _, err := dst.State.Role("1", "1"); err != nil {
+		t.Errorf("expected a role, got %v", err)
+	}
+	if _, err := dst.State.Channel("4"); err != nil {
+		t.Errorf("expected the private channel, got %v", err)
+	}
+	if m, err := dst.State.Message("2", "10"); err != nil || m.Content != "a" {
+		t.Errorf("unexpected message %+v, %v", m, err)
+	}
+
+	cached := NewState()
+	cached.MessageCache = NewMessageCache(MessageCachePolicy{}, 0)
+	if _, err := cached.ReadSnapshot(bytes.NewReader(buf.Bytes()), 0); err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := cached.MessageCache.Get("10"); !ok || m.Content != "a" {
+		t.Errorf("expected the message to be restored into the cache, got %+v", m)
+	}
+}
+
+func TestSnapshotRejected(t *testing.T) {
+	write := func(snapshot string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(snapshot))
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name     string
+		snapshot []byte
+		err      error
+	}{
+		{"version", write(`{"version":0,"api_version":"` + APIVersion + `","created_at":"` + time.Now().Format(time.RFC3339) + `","ready":{}}`), ErrSnapshotVersion},
+		{"api version", write(`{"version":1,"api_version":"6","created_at":"` + time.Now().Format(time.RFC3339) + `","ready":{}}`), ErrSnapshotVersion},
+		{"stale", write(`{"version":1,"api_version":"` + APIVersion + `","created_at":"2020-01-01T00:00:00Z","ready":{}}`), ErrSnapshotStale},
+	}
+	for _, tt := range tests {
+		_, err := NewState().ReadSnapshot(bytes.NewReader(tt.snapshot), time.Hour)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.err, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	state := NewStateWithStorage(&countingStateStorage{memoryStateStorage: newMemoryStateStorage(&Ready{})})
+	if err := state.WriteSnapshot(&buf, 0); err != ErrSnapshotStorage {
+		t.Errorf("expected ErrSnapshotStorage, got %v", err)
+	}
+}
+
+func TestSaveSnapshotOpenSession(t *testing.T) {
+	s := &Session{State: NewState(), sequence: new(int64), wsConn: &websocket.Conn{}}
+	if err := s.SaveSnapshot(&bytes.Buffer{}); err != ErrWSAlreadyOpen {
+		t.Errorf("expected ErrWSAlreadyOpen, got %v", err)
+	}
+}
+
+func TestSnapshotReplacedByReady(t *testing.T) {
+	src := NewState()
+	if err := src.OnInterface(&Session{StateEnabled: true}, stateTestReady()); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := src.WriteSnapshot(&buf, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	state := NewState()
+	if _, err := state.ReadSnapshot(&buf, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := state.OnInterface(&Session{StateEnabled: true}, &Ready{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := state.Guild("1"); err != ErrStateNotFound {
+		t.Errorf("expected the guild of the snapshot to be removed, got %v", err)
+	}
+	if _, err := state.Channel("2"); err != ErrStateNotFound {
+		t.Errorf("expected the channel of the snapshot to be removed, got %v", err)
+	}
+}
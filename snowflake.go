@@ -0,0 +1,120 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the Snowflake type for the IDs of Discord.
+
+package discordgo
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// discordEpoch is the first millisecond of 2015, the epoch of Snowflake IDs.
+const discordEpoch = 1420070400000
+
+// A Snowflake is an ID of Discord. It contains the time it was created at,
+// the IDs of the worker and process which created it and an increment.
+// It is encoded as a string in JSON, like Discord does.
+// https://discord.com/developers/docs/reference#snowflakes
+type Snowflake uint64
+
+// ParseSnowflake parses a Snowflake from its decimal string form.
+func ParseSnowflake(ID string) (Snowflake, error) {
+	i, err := strconv.ParseUint(ID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid snowflake %q: %w", ID, err)
+	}
+	return Snowflake(i), nil
+}
+
+// NewSnowflake builds a Snowflake from its parts.
+// t         : The creation time, with millisecond precision. Times before 2015 are clamped to 2015.
+// workerID  : The ID of the worker, 5 bits.
+// processID : The ID of the process, 5 bits.
+// increment : The increment, 12 bits.
+func NewSnowflake(t time.Time, workerID, processID uint8, increment uint16) Snowflake {
+	ms := t.UnixNano()/int64(time.Millisecond) - discordEpoch
+	if ms < 0 {
+		ms = 0
+	}
+	return Snowflake(uint64(ms)<<22 | uint64(workerID&0x1f)<<17 | uint64(processID&0x1f)<<12 | uint64(increment&0xfff))
+}
+
+// SnowflakeFromTime returns the smallest Snowflake created at t. It can be
+// used as a cursor to request objects by date, e.g. the messages after t:
+//
+//	s.ChannelMessages(channelID, 100, "", discordgo.SnowflakeFromTime(t).String(), "")
+func SnowflakeFromTime(t time.Time) Snowflake {
+	return NewSnowflake(t, 0, 0, 0)
+}
+
+// String returns the decimal form of the Snowflake, as used by the API.
+func (s Snowflake) String() string {
+	return strconv.FormatUint(uint64(s), 10)
+}
+
+// Time returns the time the Snowflake was created at.
+func (s Snowflake) Time() time.Time {
+	ms := int64(s>>22) + discordEpoch
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond))
+}
+
+// WorkerID returns the ID of the worker which created the Snowflake.
+func (s Snowflake) WorkerID() uint8 {
+	return uint8(s >> 17 & 0x1f)
+}
+
+// ProcessID returns the ID of the process which created the Snowflake.
+func (s Snowflake) ProcessID() uint8 {
+	return uint8(s >> 12 & 0x1f)
+}
+
+// Increment returns the increment of the Snowflake, which is incremented
+// for every ID generated by the process.
+func (s Snowflake) Increment() uint16 {
+	return uint16(s & 0xfff)
+}
+
+// Before returns true if s is smaller, and so older, than other.
+func (s Snowflake) Before(other Snowflake) bool {
+	return s < other
+}
+
+// After returns true if s is greater, and so newer, than other.
+func (s Snowflake) After(other Snowflake) bool {
+	return s > other
+}
+
+// IsZero returns true for the zero Snowflake, which isn't a valid ID.
+func (s Snowflake) IsZero() bool {
+	return s == 0
+}
+
+// MarshalJSON encodes the Snowflake as a string.
+func (s Snowflake) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(s.String())), nil
+}
+
+// UnmarshalJSON decodes a Snowflake from a string or a number.
+// Null and empty strings are decoded as the zero Snowflake.
+func (s *Snowflake) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*s = 0
+		return nil
+	}
+
+	id, err := ParseSnowflake(string(data))
+	if err != nil {
+		return err
+	}
+	*s = id
+	return nil
+}
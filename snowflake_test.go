@@ -0,0 +1,59 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSnowflake(t *testing.T) {
+	// The example of the documentation of Discord.
+	s, err := ParseSnowflake("175928847299117063")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := time.Unix(1462015105, 796*int64(time.Millisecond)); !s.Time().Equal(want) {
+		t.Errorf("expected %s, got %s", want, s.Time())
+	}
+	if s.WorkerID() != 1 || s.ProcessID() != 0 || s.Increment() != 7 {
+		t.Errorf("unexpected parts %d, %d, %d", s.WorkerID(), s.ProcessID(), s.Increment())
+	}
+	if built := NewSnowflake(s.Time(), 1, 0, 7); built != s {
+		t.Errorf("expected %s, got %s", s, built)
+	}
+
+	from := SnowflakeFromTime(s.Time())
+	if !from.Before(s) || !s.After(from) || !from.Time().Equal(s.Time()) {
+		t.Errorf("unexpected snowflake %s for %s", from, s.Time())
+	}
+	if SnowflakeFromTime(time.Unix(0, 0)) != 0 {
+		t.Errorf("expected times before 2015 to be clamped")
+	}
+
+	if _, err = ParseSnowflake("abc"); err == nil {
+		t.Errorf("expected an error for an invalid snowflake")
+	}
+}
+
+func TestSnowflakeJSON(t *testing.T) {
+	var v struct {
+		A Snowflake `json:"a"`
+		B Snowflake `json:"b"`
+		C Snowflake `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"175928847299117063","b":42,"c":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A != 175928847299117063 || v.B != 42 || !v.C.IsZero() {
+		t.Errorf("unexpected snowflakes %+v", v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"a":"175928847299117063","b":"42","c":"0"}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
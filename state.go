@@ -41,14 +41,16 @@ type State struct {
 	TrackVoice      bool
 	TrackPresences  bool
 
-	guildMap   map[string]*Guild
-	channelMap map[string]*Channel
-	memberMap  map[string]map[string]*Member
+	// MessageCache, if set, caches the messages instead of the channels,
+	// MaxMessageCount is ignored then.
+	MessageCache *MessageCache
+
+	storage StateStorage
 }
 
 // NewState creates an empty state.
 func NewState() *State {
-	return &State{
+	s := &State{
 		Ready: Ready{
 			PrivateChannels: []*Channel{},
 			Guilds:          []*Guild{},
@@ -59,18 +61,19 @@ func NewState() *State {
 		TrackRoles:     true,
 		TrackVoice:     true,
 		TrackPresences: true,
-		guildMap:       make(map[string]*Guild),
-		channelMap:     make(map[string]*Channel),
-		memberMap:      make(map[string]map[string]*Member),
 	}
+	s.storage = newMemoryStateStorage(&s.Ready)
+	return s
 }
 
-func (s *State) createMemberMap(guild *Guild) {
-	members := make(map[string]*Member)
-	for _, m := range guild.Members {
-		members[m.User.ID] = m
-	}
-	s.memberMap[guild.ID] = members
+// NewStateWithStorage creates an empty state which keeps the tracked objects
+// in storage instead of in memory. The Guilds and PrivateChannels of the
+// Ready of such a state aren't kept up to date, use the getters of the state.
+//     s.State = discordgo.NewStateWithStorage(storage)
+func NewStateWithStorage(storage StateStorage) *State {
+	s := NewState()
+	s.storage = storage
+	return s
 }
 
 // GuildAdd adds a guild to the current world state, or
@@ -83,20 +86,7 @@ func (s *State) GuildAdd(guild *Guild) error {
 	s.Lock()
 	defer s.Unlock()
 
-	// Update the channels to point to the right guild, adding them to the channelMap as we go
-	for _, c := range guild.Channels {
-		s.channelMap[c.ID] = c
-	}
-
-	// If this guild contains a new member slice, we must regenerate the member map so the pointers stay valid
-	if guild.Members != nil {
-		s.createMemberMap(guild)
-	} else if _, ok := s.memberMap[guild.ID]; !ok {
-		// Even if we have no new member slice, we still initialize the member map for this guild if it doesn't exist
-		s.memberMap[guild.ID] = make(map[string]*Member)
-	}
-
-	if g, ok := s.guildMap[guild.ID]; ok {
+	if g, err := s.storage.Guild(guild.ID); err == nil {
 		// We are about to replace `g` in the state with `guild`, but first we need to
 		// make sure we preserve any fields that the `guild` doesn't contain from `g`.
 		if guild.MemberCount == 0 {
@@ -120,14 +110,9 @@ func (s *State) GuildAdd(guild *Guild) error {
 		if guild.VoiceStates == nil {
 			guild.VoiceStates = g.VoiceStates
 		}
-		*g = *guild
-		return nil
 	}
 
-	s.Guilds = append(s.Guilds, guild)
-	s.guildMap[guild.ID] = guild
-
-	return nil
+	return s.storage.PutGuild(guild)
 }
 
 // GuildRemove removes a guild from current world state.
@@ -136,24 +121,39 @@ func (s *State) GuildRemove(guild *Guild) error {
 		return ErrNilState
 	}
 
-	_, err := s.Guild(guild.ID)
-	if err != nil {
+	s.Lock()
+	defer s.Unlock()
+
+	// The messages of the guild's channels are removed from the cache.
+	var channels []*Channel
+	if s.MessageCache != nil {
+		if g, err := s.storage.Guild(guild.ID); err == nil {
+			channels = g.Channels
+		}
+	}
+
+	if err := s.storage.DeleteGuild(guild.ID); err != nil {
 		return err
 	}
 
+	for _, c := range channels {
+		s.MessageCache.RemoveChannel(c.ID)
+	}
+	return nil
+}
+
+// guildMemberCountAdd adds delta to the MemberCount of a guild.
+func (s *State) guildMemberCountAdd(guildID string, delta int) error {
 	s.Lock()
 	defer s.Unlock()
 
-	delete(s.guildMap, guild.ID)
-
-	for i, g := range s.Guilds {
-		if g.ID == guild.ID {
-			s.Guilds = append(s.Guilds[:i], s.Guilds[i+1:]...)
-			return nil
-		}
+	guild, err := s.storage.Guild(guildID)
+	if err != nil {
+		return err
 	}
+	guild.MemberCount += delta
 
-	return nil
+	return s.storage.PutGuild(guild)
 }
 
 // Guild gets a guild by ID.
@@ -168,11 +168,7 @@ func (s *State) Guild(guildID string) (*Guild, error) {
 	s.RLock()
 	defer s.RUnlock()
 
-	if g, ok := s.guildMap[guildID]; ok {
-		return g, nil
-	}
-
-	return nil, ErrStateNotFound
+	return s.storage.Guild(guildID)
 }
 
 // PresenceAdd adds a presence to the current world state, or
@@ -182,50 +178,40 @@ func (s *State) PresenceAdd(guildID string, presence *Presence) error {
 		return ErrNilState
 	}
 
-	guild, err := s.Guild(guildID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
-	for i, p := range guild.Presences {
-		if p.User.ID == presence.User.ID {
-			//guild.Presences[i] = presence
-
-			//Update status
-			guild.Presences[i].Activities = presence.Activities
-			if presence.Status != "" {
-				guild.Presences[i].Status = presence.Status
-			}
+	if p, err := s.storage.Presence(guildID, presence.User.ID); err == nil {
+		//Update status
+		p.Activities = presence.Activities
+		if presence.Status != "" {
+			p.Status = presence.Status
+		}
 
-			//Update the optionally sent user information
-			//ID Is a mandatory field so you should not need to check if it is empty
-			guild.Presences[i].User.ID = presence.User.ID
+		//Update the optionally sent user information
+		//ID Is a mandatory field so you should not need to check if it is empty
+		p.User.ID = presence.User.ID
 
-			if presence.User.Avatar != "" {
-				guild.Presences[i].User.Avatar = presence.User.Avatar
-			}
-			if presence.User.Discriminator != "" {
-				guild.Presences[i].User.Discriminator = presence.User.Discriminator
-			}
-			if presence.User.Email != "" {
-				guild.Presences[i].User.Email = presence.User.Email
-			}
-			if presence.User.Token != "" {
-				guild.Presences[i].User.Token = presence.User.Token
-			}
-			if presence.User.Username != "" {
-				guild.Presences[i].User.Username = presence.User.Username
-			}
-
-			return nil
+		if presence.User.Avatar != "" {
+			p.User.Avatar = presence.User.Avatar
 		}
+		if presence.User.Discriminator != "" {
+			p.User.Discriminator = presence.User.Discriminator
+		}
+		if presence.User.Email != "" {
+			p.User.Email = presence.User.Email
+		}
+		if presence.User.Token != "" {
+			p.User.Token = presence.User.Token
+		}
+		if presence.User.Username != "" {
+			p.User.Username = presence.User.Username
+		}
+
+		presence = p
 	}
 
-	guild.Presences = append(guild.Presences, presence)
-	return nil
+	return s.storage.PutPresence(guildID, presence)
 }
 
 // PresenceRemove removes a presence from the current world state.
@@ -234,22 +220,10 @@ func (s *State) PresenceRemove(guildID string, presence *Presence) error {
 		return ErrNilState
 	}
 
-	guild, err := s.Guild(guildID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
-	for i, p := range guild.Presences {
-		if p.User.ID == presence.User.ID {
-			guild.Presences = append(guild.Presences[:i], guild.Presences[i+1:]...)
-			return nil
-		}
-	}
-
-	return ErrStateNotFound
+	return s.storage.DeletePresence(guildID, presence.User.ID)
 }
 
 // Presence gets a presence by ID from a guild.
@@ -258,18 +232,10 @@ func (s *State) Presence(guildID, userID string) (*Presence, error) {
 		return nil, ErrNilState
 	}
 
-	guild, err := s.Guild(guildID)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, p := range guild.Presences {
-		if p.User.ID == userID {
-			return p, nil
-		}
-	}
+	s.RLock()
+	defer s.RUnlock()
 
-	return nil, ErrStateNotFound
+	return s.storage.Presence(guildID, userID)
 }
 
 // TODO: Consider moving Guild state update methods onto *Guild.
@@ -281,33 +247,17 @@ func (s *State) MemberAdd(member *Member) error {
 		return ErrNilState
 	}
 
-	guild, err := s.Guild(member.GuildID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
-	members, ok := s.memberMap[member.GuildID]
-	if !ok {
-		return ErrStateNotFound
-	}
-
-	m, ok := members[member.User.ID]
-	if !ok {
-		members[member.User.ID] = member
-		guild.Members = append(guild.Members, member)
-	} else {
-		// We are about to replace `m` in the state with `member`, but first we need to
-		// make sure we preserve any fields that the `member` doesn't contain from `m`.
+	// Preserve the fields that the `member` doesn't contain from the stored member.
+	if m, err := s.storage.Member(member.GuildID, member.User.ID); err == nil {
 		if member.JoinedAt == "" {
 			member.JoinedAt = m.JoinedAt
 		}
-		*m = *member
 	}
 
-	return nil
+	return s.storage.PutMember(member)
 }
 
 // MemberRemove removes a member from current world state.
@@ -316,33 +266,10 @@ func (s *State) MemberRemove(member *Member) error {
 		return ErrNilState
 	}
 
-	guild, err := s.Guild(member.GuildID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
-	members, ok := s.memberMap[member.GuildID]
-	if !ok {
-		return ErrStateNotFound
-	}
-
-	_, ok = members[member.User.ID]
-	if !ok {
-		return ErrStateNotFound
-	}
-	delete(members, member.User.ID)
-
-	for i, m := range guild.Members {
-		if m.User.ID == member.User.ID {
-			guild.Members = append(guild.Members[:i], guild.Members[i+1:]...)
-			return nil
-		}
-	}
-
-	return ErrStateNotFound
+	return s.storage.DeleteMember(member.GuildID, member.User.ID)
 }
 
 // Member gets a member by ID from a guild.
@@ -354,17 +281,7 @@ func (s *State) Member(guildID, userID string) (*Member, error) {
 	s.RLock()
 	defer s.RUnlock()
 
-	members, ok := s.memberMap[guildID]
-	if !ok {
-		return nil, ErrStateNotFound
-	}
-
-	m, ok := members[userID]
-	if ok {
-		return m, nil
-	}
-
-	return nil, ErrStateNotFound
+	return s.storage.Member(guildID, userID)
 }
 
 // RoleAdd adds a role to the current world state, or
@@ -374,23 +291,10 @@ func (s *State) RoleAdd(guildID string, role *Role) error {
 		return ErrNilState
 	}
 
-	guild, err := s.Guild(guildID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
-	for i, r := range guild.Roles {
-		if r.ID == role.ID {
-			guild.Roles[i] = role
-			return nil
-		}
-	}
-
-	guild.Roles = append(guild.Roles, role)
-	return nil
+	return s.storage.PutRole(guildID, role)
 }
 
 // RoleRemove removes a role from current world state by ID.
@@ -399,22 +303,10 @@ func (s *State) RoleRemove(guildID, roleID string) error {
 		return ErrNilState
 	}
 
-	guild, err := s.Guild(guildID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
-	for i, r := range guild.Roles {
-		if r.ID == roleID {
-			guild.Roles = append(guild.Roles[:i], guild.Roles[i+1:]...)
-			return nil
-		}
-	}
-
-	return ErrStateNotFound
+	return s.storage.DeleteRole(guildID, roleID)
 }
 
 // Role gets a role by ID from a guild.
@@ -423,21 +315,10 @@ func (s *State) Role(guildID, roleID string) (*Role, error) {
 		return nil, ErrNilState
 	}
 
-	guild, err := s.Guild(guildID)
-	if err != nil {
-		return nil, err
-	}
-
 	s.RLock()
 	defer s.RUnlock()
 
-	for _, r := range guild.Roles {
-		if r.ID == roleID {
-			return r, nil
-		}
-	}
-
-	return nil, ErrStateNotFound
+	return s.storage.Role(guildID, roleID)
 }
 
 // ChannelAdd adds a channel to the current world state, or
@@ -452,33 +333,17 @@ func (s *State) ChannelAdd(channel *Channel) error {
 	s.Lock()
 	defer s.Unlock()
 
-	// If the channel exists, replace it
-	if c, ok := s.channelMap[channel.ID]; ok {
+	// If the channel exists, preserve the fields that the `channel` doesn't contain
+	if c, err := s.storage.Channel(channel.ID); err == nil {
 		if channel.Messages == nil {
 			channel.Messages = c.Messages
 		}
 		if channel.PermissionOverwrites == nil {
 			channel.PermissionOverwrites = c.PermissionOverwrites
 		}
-
-		*c = *channel
-		return nil
-	}
-
-	if channel.Type == ChannelTypeDM || channel.Type == ChannelTypeGroupDM {
-		s.PrivateChannels = append(s.PrivateChannels, channel)
-	} else {
-		guild, ok := s.guildMap[channel.GuildID]
-		if !ok {
-			return ErrStateNotFound
-		}
-
-		guild.Channels = append(guild.Channels, channel)
 	}
 
-	s.channelMap[channel.ID] = channel
-
-	return nil
+	return s.storage.PutChannel(channel)
 }
 
 // ChannelRemove removes a channel from current world state.
@@ -487,40 +352,16 @@ func (s *State) ChannelRemove(channel *Channel) error {
 		return ErrNilState
 	}
 
-	_, err := s.Channel(channel.ID)
-	if err != nil {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.storage.DeleteChannel(channel.ID); err != nil {
 		return err
 	}
 
-	if channel.Type == ChannelTypeDM || channel.Type == ChannelTypeGroupDM {
-		s.Lock()
-		defer s.Unlock()
-
-		for i, c := range s.PrivateChannels {
-			if c.ID == channel.ID {
-				s.PrivateChannels = append(s.PrivateChannels[:i], s.PrivateChannels[i+1:]...)
-				break
-			}
-		}
-	} else {
-		guild, err := s.Guild(channel.GuildID)
-		if err != nil {
-			return err
-		}
-
-		s.Lock()
-		defer s.Unlock()
-
-		for i, c := range guild.Channels {
-			if c.ID == channel.ID {
-				guild.Channels = append(guild.Channels[:i], guild.Channels[i+1:]...)
-				break
-			}
-		}
+	if s.MessageCache != nil {
+		s.MessageCache.RemoveChannel(channel.ID)
 	}
-
-	delete(s.channelMap, channel.ID)
-
 	return nil
 }
 
@@ -545,11 +386,7 @@ func (s *State) Channel(channelID string) (*Channel, error) {
 	s.RLock()
 	defer s.RUnlock()
 
-	if c, ok := s.channelMap[channelID]; ok {
-		return c, nil
-	}
-
-	return nil, ErrStateNotFound
+	return s.storage.Channel(channelID)
 }
 
 // Emoji returns an emoji for a guild and emoji id.
@@ -558,21 +395,10 @@ func (s *State) Emoji(guildID, emojiID string) (*Emoji, error) {
 		return nil, ErrNilState
 	}
 
-	guild, err := s.Guild(guildID)
-	if err != nil {
-		return nil, err
-	}
-
 	s.RLock()
 	defer s.RUnlock()
 
-	for _, e := range guild.Emojis {
-		if string(e.ID) == emojiID {
-			return e, nil
-		}
-	}
-
-	return nil, ErrStateNotFound
+	return s.storage.Emoji(guildID, emojiID)
 }
 
 // EmojiAdd adds an emoji to the current world state.
@@ -581,23 +407,10 @@ func (s *State) EmojiAdd(guildID string, emoji *Emoji) error {
 		return ErrNilState
 	}
 
-	guild, err := s.Guild(guildID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
-	for i, e := range guild.Emojis {
-		if e.ID == emoji.ID {
-			guild.Emojis[i] = emoji
-			return nil
-		}
-	}
-
-	guild.Emojis = append(guild.Emojis, emoji)
-	return nil
+	return s.storage.PutEmoji(guildID, emoji)
 }
 
 // EmojisAdd adds multiple emojis to the world state.
@@ -612,54 +425,51 @@ func (s *State) EmojisAdd(guildID string, emojis []*Emoji) error {
 
 // MessageAdd adds a message to the current world state, or updates it if it exists.
 // If the channel cannot be found, the message is discarded.
-// Messages are kept in state up to s.MaxMessageCount per channel.
+// Messages are kept in state up to s.MaxMessageCount per channel,
+// or according to the policies of s.MessageCache if it's set.
 func (s *State) MessageAdd(message *Message) error {
 	if s == nil {
 		return ErrNilState
 	}
 
-	c, err := s.Channel(message.ChannelID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
 	// If the message exists, merge in the new message contents.
-	for _, m := range c.Messages {
-		if m.ID == message.ID {
-			if message.Content != "" {
-				m.Content = message.Content
-			}
-			if message.EditedTimestamp != "" {
-				m.EditedTimestamp = message.EditedTimestamp
-			}
-			if message.Mentions != nil {
-				m.Mentions = message.Mentions
-			}
-			if message.Embeds != nil {
-				m.Embeds = message.Embeds
-			}
-			if message.Attachments != nil {
-				m.Attachments = message.Attachments
-			}
-			if message.Timestamp != "" {
-				m.Timestamp = message.Timestamp
-			}
-			if message.Author != nil {
-				m.Author = message.Author
-			}
-
-			return nil
+	if m, err := s.message(message.ChannelID, message.ID); err == nil {
+		if message.Content != "" {
+			m.Content = message.Content
+		}
+		if message.EditedTimestamp != "" {
+			m.EditedTimestamp = message.EditedTimestamp
+		}
+		if message.Mentions != nil {
+			m.Mentions = message.Mentions
+		}
+		if message.Embeds != nil {
+			m.Embeds = message.Embeds
+		}
+		if message.Attachments != nil {
+			m.Attachments = message.Attachments
 		}
+		if message.Timestamp != "" {
+			m.Timestamp = message.Timestamp
+		}
+		if message.Author != nil {
+			m.Author = message.Author
+		}
+
+		message = m
 	}
 
-	c.Messages = append(c.Messages, message)
+	if s.MessageCache == nil {
+		return s.storage.PutMessage(message, s.MaxMessageCount)
+	}
 
-	if len(c.Messages) > s.MaxMessageCount {
-		c.Messages = c.Messages[len(c.Messages)-s.MaxMessageCount:]
+	if _, err := s.storage.Channel(message.ChannelID); err != nil {
+		return err
 	}
+	s.MessageCache.Add(message)
 	return nil
 }
 
@@ -674,53 +484,50 @@ func (s *State) MessageRemove(message *Message) error {
 
 // messageRemoveByID removes a message by channelID and messageID from the world state.
 func (s *State) messageRemoveByID(channelID, messageID string) error {
-	c, err := s.Channel(channelID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
-	for i, m := range c.Messages {
-		if m.ID == messageID {
-			c.Messages = append(c.Messages[:i], c.Messages[i+1:]...)
-			return nil
-		}
+	if s.MessageCache == nil {
+		return s.storage.DeleteMessage(channelID, messageID)
 	}
 
-	return ErrStateNotFound
+	if m, ok := s.MessageCache.Get(messageID); !ok || m.ChannelID != channelID {
+		return ErrStateNotFound
+	}
+	s.MessageCache.Remove(messageID)
+	return nil
 }
 
 func (s *State) voiceStateUpdate(update *VoiceStateUpdate) error {
-	guild, err := s.Guild(update.GuildID)
-	if err != nil {
-		return err
-	}
-
 	s.Lock()
 	defer s.Unlock()
 
+	if _, err := s.storage.Guild(update.GuildID); err != nil {
+		return err
+	}
+
 	// Handle Leaving Channel
 	if update.ChannelID == "" {
-		for i, state := range guild.VoiceStates {
-			if state.UserID == update.UserID {
-				guild.VoiceStates = append(guild.VoiceStates[:i], guild.VoiceStates[i+1:]...)
-				return nil
-			}
-		}
-	} else {
-		for i, state := range guild.VoiceStates {
-			if state.UserID == update.UserID {
-				guild.VoiceStates[i] = update.VoiceState
-				return nil
-			}
+		err := s.storage.DeleteVoiceState(update.GuildID, update.UserID)
+		if err == ErrStateNotFound {
+			return nil
 		}
+		return err
+	}
+
+	return s.storage.PutVoiceState(update.GuildID, update.VoiceState)
+}
 
-		guild.VoiceStates = append(guild.VoiceStates, update.VoiceState)
+// VoiceState gets the voice state of a user in a guild.
+func (s *State) VoiceState(guildID, userID string) (*VoiceState, error) {
+	if s == nil {
+		return nil, ErrNilState
 	}
 
-	return nil
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.storage.VoiceState(guildID, userID)
 }
 
 // Message gets a message by channel and message ID.
@@ -729,23 +536,29 @@ func (s *State) Message(channelID, messageID string) (*Message, error) {
 		return nil, ErrNilState
 	}
 
-	c, err := s.Channel(channelID)
-	if err != nil {
-		return nil, err
-	}
-
 	s.RLock()
 	defer s.RUnlock()
 
-	for _, m := range c.Messages {
-		if m.ID == messageID {
-			return m, nil
-		}
+	return s.message(channelID, messageID)
+}
+
+// message gets a message from the MessageCache if it's set, or else from the storage.
+func (s *State) message(channelID, messageID string) (*Message, error) {
+	if s.MessageCache == nil {
+		return s.storage.Message(channelID, messageID)
 	}
 
+	if m, ok := s.MessageCache.Get(messageID); ok && m.ChannelID == channelID {
+		return m, nil
+	}
 	return nil, ErrStateNotFound
 }
 
+// tracksMessages returns whether messages are kept in the state.
+func (s *State) tracksMessages() bool {
+	return s.MaxMessageCount != 0 || s.MessageCache != nil
+}
+
 // OnReady takes a Ready event and updates all internal state.
 func (s *State) onReady(se *Session, r *Ready) (err error) {
 	if s == nil {
@@ -771,20 +584,59 @@ func (s *State) onReady(se *Session, r *Ready) (err error) {
 
 	s.Ready = *r
 
-	for _, g := range s.Guilds {
-		s.guildMap[g.ID] = g
-		s.createMemberMap(g)
+	return s.storage.Load(&s.Ready)
+}
+
+// copyGuild returns a copy of a cached guild for the before-objects of events.
+// The State changes cached objects in place, so the copy doesn't share the
+// slices which are changed later. Guild updates don't carry members,
+// presences, channels or voice states, so they are left out instead of
+// copying them for every event.
+func copyGuild(g *Guild) *Guild {
+	guildCopy := *g
+	guildCopy.Roles = append([]*Role(nil), g.Roles...)
+	guildCopy.Emojis = append([]*Emoji(nil), g.Emojis...)
+
+	guildCopy.Members = nil
+	guildCopy.Presences = nil
+	guildCopy.Channels = nil
+	guildCopy.VoiceStates = nil
+	return &guildCopy
+}
+
+// copyChannel returns a copy of a cached channel, see copyGuild.
+func copyChannel(c *Channel) *Channel {
+	channelCopy := *c
+	channelCopy.PermissionOverwrites = append([]*PermissionOverwrite(nil), c.PermissionOverwrites...)
 
-		for _, c := range g.Channels {
-			s.channelMap[c.ID] = c
+	if c.Messages != nil {
+		channelCopy.Messages = make([]*Message, len(c.Messages))
+		for i, m := range c.Messages {
+			messageCopy := *m
+			channelCopy.Messages[i] = &messageCopy
 		}
 	}
+	return &channelCopy
+}
 
-	for _, c := range s.PrivateChannels {
-		s.channelMap[c.ID] = c
+// copyMember returns a copy of a cached member, see copyGuild.
+func copyMember(m *Member) *Member {
+	memberCopy := *m
+	if m.User != nil {
+		userCopy := *m.User
+		memberCopy.User = &userCopy
 	}
+	return &memberCopy
+}
 
-	return nil
+// copyPresence returns a copy of a cached presence, see copyGuild.
+func copyPresence(p *Presence) *Presence {
+	presenceCopy := *p
+	if p.User != nil {
+		userCopy := *p.User
+		presenceCopy.User = &userCopy
+	}
+	return &presenceCopy
 }
 
 // OnInterface handles all events related to states.
@@ -806,16 +658,22 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 	case *GuildCreate:
 		err = s.GuildAdd(t.Guild)
 	case *GuildUpdate:
+		if old, err := s.Guild(t.ID); err == nil {
+			t.BeforeUpdate = copyGuild(old)
+		}
+
 		err = s.GuildAdd(t.Guild)
 	case *GuildDelete:
+		if old, err := s.Guild(t.ID); err == nil {
+			t.BeforeDelete = copyGuild(old)
+		}
+
 		err = s.GuildRemove(t.Guild)
 	case *GuildMemberAdd:
 		// Updates the MemberCount of the guild.
-		guild, err := s.Guild(t.Member.GuildID)
-		if err != nil {
+		if err = s.guildMemberCountAdd(t.Member.GuildID, 1); err != nil {
 			return err
 		}
-		guild.MemberCount++
 
 		// Caches member if tracking is enabled.
 		if s.TrackMembers {
@@ -823,18 +681,24 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 		}
 	case *GuildMemberUpdate:
 		if s.TrackMembers {
+			if old, err := s.Member(t.GuildID, t.User.ID); err == nil {
+				t.BeforeUpdate = copyMember(old)
+			}
+
 			err = s.MemberAdd(t.Member)
 		}
 	case *GuildMemberRemove:
 		// Updates the MemberCount of the guild.
-		guild, err := s.Guild(t.Member.GuildID)
-		if err != nil {
+		if err = s.guildMemberCountAdd(t.Member.GuildID, -1); err != nil {
 			return err
 		}
-		guild.MemberCount--
 
 		// Removes member from the cache if tracking is enabled.
 		if s.TrackMembers {
+			if old, err := s.Member(t.GuildID, t.User.ID); err == nil {
+				t.BeforeDelete = copyMember(old)
+			}
+
 			err = s.MemberRemove(t.Member)
 		}
 	case *GuildMembersChunk:
@@ -850,14 +714,28 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 		}
 	case *GuildRoleUpdate:
 		if s.TrackRoles {
+			if old, err := s.Role(t.GuildID, t.Role.ID); err == nil {
+				oldCopy := *old
+				t.BeforeUpdate = &oldCopy
+			}
+
 			err = s.RoleAdd(t.GuildID, t.Role)
 		}
 	case *GuildRoleDelete:
 		if s.TrackRoles {
+			if old, err := s.Role(t.GuildID, t.RoleID); err == nil {
+				oldCopy := *old
+				t.BeforeDelete = &oldCopy
+			}
+
 			err = s.RoleRemove(t.GuildID, t.RoleID)
 		}
 	case *GuildEmojisUpdate:
 		if s.TrackEmojis {
+			if old, err := s.Guild(t.GuildID); err == nil {
+				t.BeforeUpdate = append([]*Emoji(nil), old.Emojis...)
+			}
+
 			err = s.EmojisAdd(t.GuildID, t.Emojis)
 		}
 	case *ChannelCreate:
@@ -866,18 +744,26 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 		}
 	case *ChannelUpdate:
 		if s.TrackChannels {
+			if old, err := s.Channel(t.ID); err == nil {
+				t.BeforeUpdate = copyChannel(old)
+			}
+
 			err = s.ChannelAdd(t.Channel)
 		}
 	case *ChannelDelete:
 		if s.TrackChannels {
+			if old, err := s.Channel(t.ID); err == nil {
+				t.BeforeDelete = copyChannel(old)
+			}
+
 			err = s.ChannelRemove(t.Channel)
 		}
 	case *MessageCreate:
-		if s.MaxMessageCount != 0 {
+		if s.tracksMessages() {
 			err = s.MessageAdd(t.Message)
 		}
 	case *MessageUpdate:
-		if s.MaxMessageCount != 0 {
+		if s.tracksMessages() {
 			var old *Message
 			old, err = s.Message(t.ChannelID, t.ID)
 			if err == nil {
@@ -888,21 +774,39 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 			err = s.MessageAdd(t.Message)
 		}
 	case *MessageDelete:
-		if s.MaxMessageCount != 0 {
+		if s.tracksMessages() {
+			if old, err := s.Message(t.ChannelID, t.ID); err == nil {
+				oldCopy := *old
+				t.BeforeDelete = &oldCopy
+			}
+
 			err = s.MessageRemove(t.Message)
 		}
 	case *MessageDeleteBulk:
-		if s.MaxMessageCount != 0 {
+		if s.tracksMessages() {
 			for _, mID := range t.Messages {
+				if old, err := s.Message(t.ChannelID, mID); err == nil {
+					oldCopy := *old
+					t.BeforeDelete = append(t.BeforeDelete, &oldCopy)
+				}
 				s.messageRemoveByID(t.ChannelID, mID)
 			}
 		}
 	case *VoiceStateUpdate:
 		if s.TrackVoice {
+			if old, err := s.VoiceState(t.GuildID, t.UserID); err == nil {
+				oldCopy := *old
+				t.BeforeUpdate = &oldCopy
+			}
+
 			err = s.voiceStateUpdate(t)
 		}
 	case *PresenceUpdate:
 		if s.TrackPresences {
+			if old, err := s.Presence(t.GuildID, t.User.ID); err == nil {
+				t.BeforeUpdate = copyPresence(old)
+			}
+
 			s.PresenceAdd(t.GuildID, t.Presence)
 		}
 		if s.TrackMembers {
@@ -937,7 +841,7 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 // UserChannelPermissions returns the permission of a user in a channel.
 // userID    : The ID of the user to calculate permissions for.
 // channelID : The ID of the channel to calculate permission for.
-func (s *State) UserChannelPermissions(userID, channelID string) (apermissions int, err error) {
+func (s *State) UserChannelPermissions(userID, channelID string) (apermissions Permissions, err error) {
 	if s == nil {
 		return 0, ErrNilState
 	}
@@ -947,6 +851,14 @@ func (s *State) UserChannelPermissions(userID, channelID string) (apermissions i
 		return
 	}
 
+	var parent *Channel
+	if channel.IsThread() {
+		parent, err = s.Channel(channel.ParentID)
+		if err != nil {
+			return
+		}
+	}
+
 	guild, err := s.Guild(channel.GuildID)
 	if err != nil {
 		return
@@ -962,7 +874,7 @@ func (s *State) UserChannelPermissions(userID, channelID string) (apermissions i
 		return
 	}
 
-	return memberPermissions(guild, channel, member), nil
+	return MemberPermissions(guild, channel, parent, member)
 }
 
 // UserColor returns the color of a user in a channel.
@@ -0,0 +1,535 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the interface of the storage behind a State and the
+// default in-memory storage.
+
+package discordgo
+
+// StateStorage stores the objects cached by a State, e.g. to share them
+// between processes or keep them across restarts.
+//
+// The State merges updates into the stored objects before putting them, so
+// a storage only has to store and look up objects. Getters return
+// ErrStateNotFound for objects which aren't stored, and so do Put methods of
+// objects of a guild which isn't stored. Calls are serialized by the lock of
+// the State.
+type StateStorage interface {
+	// Load stores the guilds and private channels of a Ready event.
+	Load(ready *Ready) error
+
+	Guild(guildID string) (*Guild, error)
+	// PutGuild stores a guild with its channels, members, roles, emojis,
+	// presences and voice states.
+	PutGuild(guild *Guild) error
+	DeleteGuild(guildID string) error
+
+	Channel(channelID string) (*Channel, error)
+	PutChannel(channel *Channel) error
+	DeleteChannel(channelID string) error
+
+	Member(guildID, userID string) (*Member, error)
+	PutMember(member *Member) error
+	DeleteMember(guildID, userID string) error
+
+	Role(guildID, roleID string) (*Role, error)
+	PutRole(guildID string, role *Role) error
+	DeleteRole(guildID, roleID string) error
+
+	Emoji(guildID, emojiID string) (*Emoji, error)
+	PutEmoji(guildID string, emoji *Emoji) error
+
+	Message(channelID, messageID string) (*Message, error)
+	// PutMessage stores a message, keeping at most limit messages of its channel.
+	PutMessage(message *Message, limit int) error
+	DeleteMessage(channelID, messageID string) error
+
+	Presence(guildID, userID string) (*Presence, error)
+	PutPresence(guildID string, presence *Presence) error
+	DeletePresence(guildID, userID string) error
+
+	VoiceState(guildID, userID string) (*VoiceState, error)
+	PutVoiceState(guildID string, state *VoiceState) error
+	DeleteVoiceState(guildID, userID string) error
+}
+
+// memoryStateStorage is the default StateStorage, it keeps the objects in
+// the Ready of the State and in maps indexing them. Objects which are
+// updated keep their pointers.
+type memoryStateStorage struct {
+	ready *Ready
+
+	guildMap   map[string]*Guild
+	channelMap map[string]*Channel
+	memberMap  map[string]map[string]*Member
+}
+
+func newMemoryStateStorage(ready *Ready) *memoryStateStorage {
+	return &memoryStateStorage{
+		ready:      ready,
+		guildMap:   make(map[string]*Guild),
+		channelMap: make(map[string]*Channel),
+		memberMap:  make(map[string]map[string]*Member),
+	}
+}
+
+func (m *memoryStateStorage) createMemberMap(guild *Guild) {
+	members := make(map[string]*Member)
+	for _, member := range guild.Members {
+		members[member.User.ID] = member
+	}
+	m.memberMap[guild.ID] = members
+}
+
+// Load implements the StateStorage interface, it indexes the objects of
+// ready, which is the Ready of the State, replacing the previous index.
+func (m *memoryStateStorage) Load(ready *Ready) error {
+	m.guildMap = make(map[string]*Guild)
+	m.channelMap = make(map[string]*Channel)
+	m.memberMap = make(map[string]map[string]*Member)
+
+	for _, g := range ready.Guilds {
+		m.guildMap[g.ID] = g
+		m.createMemberMap(g)
+
+		for _, c := range g.Channels {
+			m.channelMap[c.ID] = c
+		}
+	}
+
+	for _, c := range ready.PrivateChannels {
+		m.channelMap[c.ID] = c
+	}
+
+	return nil
+}
+
+// Guild implements the StateStorage interface.
+func (m *memoryStateStorage) Guild(guildID string) (*Guild, error) {
+	if g, ok := m.guildMap[guildID]; ok {
+		return g, nil
+	}
+
+	return nil, ErrStateNotFound
+}
+
+// PutGuild implements the StateStorage interface.
+func (m *memoryStateStorage) PutGuild(guild *Guild) error {
+	// Update the channels to point to the right guild, adding them to the channelMap as we go
+	for _, c := range guild.Channels {
+		m.channelMap[c.ID] = c
+	}
+
+	// If this guild contains a new member slice, we must regenerate the member map so the pointers stay valid
+	if guild.Members != nil {
+		m.createMemberMap(guild)
+	} else if _, ok := m.memberMap[guild.ID]; !ok {
+		// Even if we have no new member slice, we still initialize the member map for this guild if it doesn't exist
+		m.memberMap[guild.ID] = make(map[string]*Member)
+	}
+
+	if g, ok := m.guildMap[guild.ID]; ok {
+		*g = *guild
+		return nil
+	}
+
+	m.ready.Guilds = append(m.ready.Guilds, guild)
+	m.guildMap[guild.ID] = guild
+
+	return nil
+}
+
+// DeleteGuild implements the StateStorage interface.
+func (m *memoryStateStorage) DeleteGuild(guildID string) error {
+	if _, ok := m.guildMap[guildID]; !ok {
+		return ErrStateNotFound
+	}
+
+	delete(m.guildMap, guildID)
+
+	for i, g := range m.ready.Guilds {
+		if g.ID == guildID {
+			m.ready.Guilds = append(m.ready.Guilds[:i], m.ready.Guilds[i+1:]...)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Channel implements the StateStorage interface.
+func (m *memoryStateStorage) Channel(channelID string) (*Channel, error) {
+	if c, ok := m.channelMap[channelID]; ok {
+		return c, nil
+	}
+
+	return nil, ErrStateNotFound
+}
+
+// PutChannel implements the StateStorage interface.
+func (m *memoryStateStorage) PutChannel(channel *Channel) error {
+	if c, ok := m.channelMap[channel.ID]; ok {
+		*c = *channel
+		return nil
+	}
+
+	if channel.Type == ChannelTypeDM || channel.Type == ChannelTypeGroupDM {
+		m.ready.PrivateChannels = append(m.ready.PrivateChannels, channel)
+	} else {
+		guild, ok := m.guildMap[channel.GuildID]
+		if !ok {
+			return ErrStateNotFound
+		}
+
+		guild.Channels = append(guild.Channels, channel)
+	}
+
+	m.channelMap[channel.ID] = channel
+
+	return nil
+}
+
+// DeleteChannel implements the StateStorage interface.
+func (m *memoryStateStorage) DeleteChannel(channelID string) error {
+	channel, ok := m.channelMap[channelID]
+	if !ok {
+		return ErrStateNotFound
+	}
+
+	if channel.Type == ChannelTypeDM || channel.Type == ChannelTypeGroupDM {
+		for i, c := range m.ready.PrivateChannels {
+			if c.ID == channelID {
+				m.ready.PrivateChannels = append(m.ready.PrivateChannels[:i], m.ready.PrivateChannels[i+1:]...)
+				break
+			}
+		}
+	} else {
+		guild, ok := m.guildMap[channel.GuildID]
+		if !ok {
+			return ErrStateNotFound
+		}
+
+		for i, c := range guild.Channels {
+			if c.ID == channelID {
+				guild.Channels = append(guild.Channels[:i], guild.Channels[i+1:]...)
+				break
+			}
+		}
+	}
+
+	delete(m.channelMap, channelID)
+
+	return nil
+}
+
+// Member implements the StateStorage interface.
+func (m *memoryStateStorage) Member(guildID, userID string) (*Member, error) {
+	members, ok := m.memberMap[guildID]
+	if !ok {
+		return nil, ErrStateNotFound
+	}
+
+	member, ok := members[userID]
+	if ok {
+		return member, nil
+	}
+
+	return nil, ErrStateNotFound
+}
+
+// PutMember implements the StateStorage interface.
+func (m *memoryStateStorage) PutMember(member *Member) error {
+	guild, ok := m.guildMap[member.GuildID]
+	if !ok {
+		return ErrStateNotFound
+	}
+
+	members, ok := m.memberMap[member.GuildID]
+	if !ok {
+		return ErrStateNotFound
+	}
+
+	old, ok := members[member.User.ID]
+	if !ok {
+		members[member.User.ID] = member
+		guild.Members = append(guild.Members, member)
+	} else {
+		*old = *member
+	}
+
+	return nil
+}
+
+// DeleteMember implements the StateStorage interface.
+func (m *memoryStateStorage) DeleteMember(guildID, userID string) error {
+	guild, ok := m.guildMap[guildID]
+	if !ok {
+		return ErrStateNotFound
+	}
+
+	members, ok := m.memberMap[guildID]
+	if !ok {
+		return ErrStateNotFound
+	}
+
+	_, ok = members[userID]
+	if !ok {
+		return ErrStateNotFound
+	}
+	delete(members, userID)
+
+	for i, member := range guild.Members {
+		if member.User.ID == userID {
+			guild.Members = append(guild.Members[:i], guild.Members[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrStateNotFound
+}
+
+// Role implements the StateStorage interface.
+func (m *memoryStateStorage) Role(guildID, roleID string) (*Role, error) {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range guild.Roles {
+		if r.ID == roleID {
+			return r, nil
+		}
+	}
+
+	return nil, ErrStateNotFound
+}
+
+// PutRole implements the StateStorage interface.
+func (m *memoryStateStorage) PutRole(guildID string, role *Role) error {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range guild.Roles {
+		if r.ID == role.ID {
+			guild.Roles[i] = role
+			return nil
+		}
+	}
+
+	guild.Roles = append(guild.Roles, role)
+	return nil
+}
+
+// DeleteRole implements the StateStorage interface.
+func (m *memoryStateStorage) DeleteRole(guildID, roleID string) error {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range guild.Roles {
+		if r.ID == roleID {
+			guild.Roles = append(guild.Roles[:i], guild.Roles[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrStateNotFound
+}
+
+// Emoji implements the StateStorage interface.
+func (m *memoryStateStorage) Emoji(guildID, emojiID string) (*Emoji, error) {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range guild.Emojis {
+		if string(e.ID) == emojiID {
+			return e, nil
+		}
+	}
+
+	return nil, ErrStateNotFound
+}
+
+// PutEmoji implements the StateStorage interface.
+func (m *memoryStateStorage) PutEmoji(guildID string, emoji *Emoji) error {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	for i, e := range guild.Emojis {
+		if e.ID == emoji.ID {
+			guild.Emojis[i] = emoji
+			return nil
+		}
+	}
+
+	guild.Emojis = append(guild.Emojis, emoji)
+	return nil
+}
+
+// Message implements the StateStorage interface.
+func (m *memoryStateStorage) Message(channelID, messageID string) (*Message, error) {
+	c, err := m.Channel(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, msg := range c.Messages {
+		if msg.ID == messageID {
+			return msg, nil
+		}
+	}
+
+	return nil, ErrStateNotFound
+}
+
+// PutMessage implements the StateStorage interface.
+func (m *memoryStateStorage) PutMessage(message *Message, limit int) error {
+	c, err := m.Channel(message.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range c.Messages {
+		if msg.ID == message.ID {
+			*msg = *message
+			return nil
+		}
+	}
+
+	c.Messages = append(c.Messages, message)
+
+	if len(c.Messages) > limit {
+		c.Messages = c.Messages[len(c.Messages)-limit:]
+	}
+	return nil
+}
+
+// DeleteMessage implements the StateStorage interface.
+func (m *memoryStateStorage) DeleteMessage(channelID, messageID string) error {
+	c, err := m.Channel(channelID)
+	if err != nil {
+		return err
+	}
+
+	for i, msg := range c.Messages {
+		if msg.ID == messageID {
+			c.Messages = append(c.Messages[:i], c.Messages[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrStateNotFound
+}
+
+// Presence implements the StateStorage interface.
+func (m *memoryStateStorage) Presence(guildID, userID string) (*Presence, error) {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range guild.Presences {
+		if p.User.ID == userID {
+			return p, nil
+		}
+	}
+
+	return nil, ErrStateNotFound
+}
+
+// PutPresence implements the StateStorage interface.
+func (m *memoryStateStorage) PutPresence(guildID string, presence *Presence) error {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range guild.Presences {
+		if p.User.ID == presence.User.ID {
+			*p = *presence
+			return nil
+		}
+	}
+
+	guild.Presences = append(guild.Presences, presence)
+	return nil
+}
+
+// DeletePresence implements the StateStorage interface.
+func (m *memoryStateStorage) DeletePresence(guildID, userID string) error {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	for i, p := range guild.Presences {
+		if p.User.ID == userID {
+			guild.Presences = append(guild.Presences[:i], guild.Presences[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrStateNotFound
+}
+
+// VoiceState implements the StateStorage interface.
+func (m *memoryStateStorage) VoiceState(guildID, userID string) (*VoiceState, error) {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, state := range guild.VoiceStates {
+		if state.UserID == userID {
+			return state, nil
+		}
+	}
+
+	return nil, ErrStateNotFound
+}
+
+// PutVoiceState implements the StateStorage interface.
+func (m *memoryStateStorage) PutVoiceState(guildID string, state *VoiceState) error {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	for i, vs := range guild.VoiceStates {
+		if vs.UserID == state.UserID {
+			guild.VoiceStates[i] = state
+			return nil
+		}
+	}
+
+	guild.VoiceStates = append(guild.VoiceStates, state)
+	return nil
+}
+
+// DeleteVoiceState implements the StateStorage interface.
+func (m *memoryStateStorage) DeleteVoiceState(guildID, userID string) error {
+	guild, err := m.Guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	for i, state := range guild.VoiceStates {
+		if state.UserID == userID {
+			guild.VoiceStates = append(guild.VoiceStates[:i], guild.VoiceStates[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrStateNotFound
+}
@@ -0,0 +1,231 @@
+package discordgo
+
+import (
+	"testing"
+)
+
+func stateTestReady() *Ready {
+	return &Ready{
+		Guilds: []*Guild{{
+			ID:       "1",
+			Channels: []*Channel{{ID: "2", GuildID: "1", Type: ChannelTypeGuildText}},
+			Members:  []*Member{{GuildID: "1", User: &User{ID: "3"}, JoinedAt: "2016-01-01T00:00:00Z"}},
+			Roles:    []*Role{{ID: "1"}},
+		}},
+		PrivateChannels: []*Channel{{ID: "4", Type: ChannelTypeDM}},
+	}
+}
+
+func testStateEvents(t *testing.T, state *State) {
+	se := &Session{StateEnabled: true}
+	state.MaxMessageCount = 2
+
+	events := []interface{}{
+		stateTestReady(),
+		&GuildMemberUpdate{Member: &Member{GuildID: "1", User: &User{ID: "3"}, Nick: "nick"}},
+		&GuildRoleCreate{&GuildRole{GuildID: "1", Role: &Role{ID: "5", Name: "role"}}},
+		&MessageCreate{&Message{ID: "10", ChannelID: "2", Content: "a"}},
+		&MessageCreate{&Message{ID: "11", ChannelID: "2", Content: "b"}},
+		&MessageCreate{&Message{ID: "12", ChannelID: "2", Content: "c"}},
+		&MessageUpdate{Message: &Message{ID: "12", ChannelID: "2", Content: "d"}},
+		&VoiceStateUpdate{VoiceState: &VoiceState{GuildID: "1", ChannelID: "2", UserID: "3"}},
+		&ChannelDelete{Channel: &Channel{ID: "4"}},
+	}
+	for _, e := range events {
+		if err := state.OnInterface(se, e); err != nil {
+			t.Fatalf("%T: %v", e, err)
+		}
+	}
+
+	if m, err := state.Member("1", "3"); err != nil || m.Nick != "nick" || m.JoinedAt == "" {
+		t.Errorf("unexpected member %+v, %v", m, err)
+	}
+	if r, err := state.Role("1", "5"); err != nil || r.Name != "role" {
+		t.Errorf("unexpected role %+v, %v", r, err)
+	}
+	if _, err := state.Message("2", "10"); err != ErrStateNotFound {
+		t.Errorf("expected the oldest message to be dropped, got %v", err)
+	}
+	if m, err := state.Message("2", "12"); err != nil || m.Content != "d" {
+		t.Errorf("unexpected message %+v, %v", m, err)
+	}
+	if _, err := state.VoiceState("1", "3"); err != nil {
+		t.Errorf("expected a voice state, got %v", err)
+	}
+	if _, err := state.Channel("4"); err != ErrStateNotFound {
+		t.Errorf("expected the channel to be deleted, got %v", err)
+	}
+
+	if err := state.OnInterface(se, &VoiceStateUpdate{VoiceState: &VoiceState{GuildID: "1", UserID: "3"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := state.VoiceState("1", "3"); err != ErrStateNotFound {
+		t.Errorf("expected the voice state to be deleted, got %v", err)
+	}
+}
+
+func TestState(t *testing.T) {
+	state := NewState()
+	testStateEvents(t, state)
+
+	if len(state.Guilds) != 1 || len(state.PrivateChannels) != 0 {
+		t.Errorf("unexpected ready %d guilds, %d private channels", len(state.Guilds), len(state.PrivateChannels))
+	}
+	if c := state.Guilds[0].Channels[0]; len(c.Messages) != 2 {
+		t.Errorf("expected 2 messages in the channel, got %d", len(c.Messages))
+	}
+}
+
+// countingStateStorage counts the messages put in a storage.
+type countingStateStorage struct {
+	*memoryStateStorage
+	messages int
+}
+
+func (c *countingStateStorage) PutMessage(message *Message, limit int) error {
+	c.messages++
+	return c.memoryStateStorage.PutMessage(message, limit)
+}
+
+func TestStateWithStorage(t *testing.T) {
+	storage := &countingStateStorage{memoryStateStorage: newMemoryStateStorage(&Ready{})}
+	testStateEvents(t, NewStateWithStorage(storage))
+
+	if storage.messages != 4 {
+		t.Errorf("expected 4 messages to be put, got %d", storage.messages)
+	}
+}
+
+// copyingStateStorage returns copies of the guilds in a storage.
+type copyingStateStorage struct {
+	*memoryStateStorage
+}
+
+func (c copyingStateStorage) Guild(guildID string) (*Guild, error) {
+	g, err := c.memoryStateStorage.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+	guildCopy := *g
+	return &guildCopy, nil
+}
+
+func TestStateMemberCount(t *testing.T) {
+	state := NewStateWithStorage(copyingStateStorage{newMemoryStateStorage(&Ready{})})
+	se := &Session{StateEnabled: true}
+
+	for _, e := range []interface{}{
+		stateTestReady(),
+		&GuildMemberAdd{&Member{GuildID: "1", User: &User{ID: "4"}}},
+		&GuildMemberAdd{&Member{GuildID: "1", User: &User{ID: "5"}}},
+		&GuildMemberRemove{Member: &Member{GuildID: "1", User: &User{ID: "4"}}},
+	} {
+		if err := state.OnInterface(se, e); err != nil {
+			t.Fatalf("%T: %v", e, err)
+		}
+	}
+
+	if g, err := state.Guild("1"); err != nil || g.MemberCount != 1 {
+		t.Errorf("expected a member count of 1, got %+v, %v", g, err)
+	}
+}
+
+func TestStateBeforeObjects(t *testing.T) {
+	state := NewState()
+	state.MaxMessageCount = 10
+	se := &Session{StateEnabled: true}
+
+	for _, e := range []interface{}{
+		stateTestReady(),
+		&MessageCreate{&Message{ID: "10", ChannelID: "2", Content: "a"}},
+		&MessageCreate{&Message{ID: "11", ChannelID: "2", Content: "b"}},
+		&MessageCreate{&Message{ID: "12", ChannelID: "2", Content: "c"}},
+		&PresenceUpdate{Presence: &Presence{GuildID: "1", User: &User{ID: "3", Username: "old"}, Status: StatusOnline}},
+	} {
+		if err := state.OnInterface(se, e); err != nil {
+			t.Fatalf("%T: %v", e, err)
+		}
+	}
+
+	channelUpdate := &ChannelUpdate{Channel: &Channel{ID: "2", GuildID: "1", Name: "new"}}
+	memberUpdate := &GuildMemberUpdate{Member: &Member{GuildID: "1", User: &User{ID: "3"}, Nick: "new"}}
+	roleUpdate := &GuildRoleUpdate{GuildRole: &GuildRole{GuildID: "1", Role: &Role{ID: "1", Name: "new"}}}
+	presenceUpdate := &PresenceUpdate{Presence: &Presence{GuildID: "1", User: &User{ID: "3", Username: "new"}, Status: StatusIdle}}
+	messageDelete := &MessageDelete{Message: &Message{ID: "10", ChannelID: "2"}}
+	messageDeleteBulk := &MessageDeleteBulk{ChannelID: "2", Messages: []string{"11", "12", "13"}}
+	roleDelete := &GuildRoleDelete{GuildID: "1", RoleID: "1"}
+	memberRemove := &GuildMemberRemove{Member: &Member{GuildID: "1", User: &User{ID: "3"}}}
+	guildUpdate := &GuildUpdate{Guild: &Guild{ID: "1", Name: "new"}}
+	guildDelete := &GuildDelete{Guild: &Guild{ID: "1"}}
+
+	for _, e := range []interface{}{channelUpdate, memberUpdate, roleUpdate, presenceUpdate, messageDelete, messageDeleteBulk, roleDelete, memberRemove, guildUpdate, guildDelete} {
+		if err := state.OnInterface(se, e); err != nil {
+			t.Fatalf("%T: %v", e, err)
+		}
+	}
+
+	if b := channelUpdate.BeforeUpdate; b == nil || b.Name != "" || len(b.Messages) != 3 {
+		t.Errorf("unexpected channel before the update %+v", b)
+	}
+	if b := memberUpdate.BeforeUpdate; b == nil || b.Nick != "" {
+		t.Errorf("unexpected member before the update %+v", b)
+	}
+	if b := roleUpdate.BeforeUpdate; b == nil || b.Name != "" {
+		t.Errorf("unexpected role before the update %+v", b)
+	}
+	if b := presenceUpdate.BeforeUpdate; b == nil || b.Status != StatusOnline || b.User.Username != "old" {
+		t.Errorf("unexpected presence before the update %+v", b)
+	}
+	if b := messageDelete.BeforeDelete; b == nil || b.Content != "a" {
+		t.Errorf("unexpected deleted message %+v", b)
+	}
+	if b := messageDeleteBulk.BeforeDelete; len(b) != 2 || b[0].Content != "b" || b[1].Content != "c" {
+		t.Errorf("unexpected deleted messages %v", b)
+	}
+	if b := roleDelete.BeforeDelete; b == nil || b.Name != "new" {
+		t.Errorf("unexpected deleted role %+v", b)
+	}
+	if b := memberRemove.BeforeDelete; b == nil || b.Nick != "new" {
+		t.Errorf("unexpected removed member %+v", b)
+	}
+	if b := guildUpdate.BeforeUpdate; b == nil || b.Name != "" {
+		t.Errorf("unexpected guild before the update %+v", b)
+	}
+	if b := guildDelete.BeforeDelete; b == nil || b.Name != "new" {
+		t.Errorf("unexpected deleted guild %+v", b)
+	}
+}
+
+func TestStateBeforeObjectsCopied(t *testing.T) {
+	state := NewState()
+	se := &Session{StateEnabled: true}
+	ready := stateTestReady()
+	ready.Guilds[0].Emojis = []*Emoji{{ID: "6", Name: "old"}}
+	if err := state.OnInterface(se, ready); err != nil {
+		t.Fatal(err)
+	}
+
+	guildUpdate := &GuildUpdate{Guild: &Guild{ID: "1", Name: "new"}}
+	emojisUpdate := &GuildEmojisUpdate{GuildID: "1", Emojis: []*Emoji{{ID: "6", Name: "new"}}}
+
+	for _, e := range []interface{}{
+		guildUpdate,
+		emojisUpdate,
+		&GuildRoleUpdate{GuildRole: &GuildRole{GuildID: "1", Role: &Role{ID: "1", Name: "new"}}},
+		&PresenceUpdate{Presence: &Presence{GuildID: "1", User: &User{ID: "3", Username: "new"}, Status: StatusOnline}},
+	} {
+		if err := state.OnInterface(se, e); err != nil {
+			t.Fatalf("%T: %v", e, err)
+		}
+	}
+
+	if b := guildUpdate.BeforeUpdate; b == nil || b.Roles[0].Name != "" {
+		t.Errorf("expected the role of the guild before the update to be kept, got %+v", b)
+	}
+	if b := guildUpdate.BeforeUpdate; b == nil || b.Members != nil || b.Channels != nil {
+		t.Errorf("expected no members and channels in the guild before the update, got %+v", b)
+	}
+	if b := emojisUpdate.BeforeUpdate; len(b) != 1 || b[0].Name != "old" {
+		t.Errorf("unexpected emojis before the update %v", b)
+	}
+}
@@ -64,6 +64,18 @@ type Session struct {
 	// Max number of REST API retries
 	MaxRestRetries int
 
+	// Decides which failed REST requests are retried and how long to wait
+	// between attempts. If nil, the policy returned by NewRetryPolicy is used.
+	RetryPolicy *RetryPolicy
+
+	// Counts invalid (401, 403 and 429) responses to avoid Discord's
+	// temporary IP ban. Share one limiter between sessions using the same IP.
+	// If nil, invalid requests are not tracked.
+	InvalidRequests *InvalidRequestLimiter
+
+	// Hooks which are called for every REST request, e.g. a MetricsCollector.
+	RequestHooks []RequestHook
+
 	// Status stores the currect status of the websocket connection
 	// this is being tested, may stay, may go away.
 	status int32
@@ -215,6 +227,10 @@ const (
 	ChannelTypeGuildCategory
 	ChannelTypeGuildNews
 	ChannelTypeGuildStore
+	ChannelTypeGuildNewsThread    ChannelType = 10
+	ChannelTypeGuildPublicThread  ChannelType = 11
+	ChannelTypeGuildPrivateThread ChannelType = 12
+	ChannelTypeGuildStageVoice    ChannelType = 13
 )
 
 // A Channel holds all data related to an individual Discord channel.
@@ -297,7 +313,8 @@ type Channel struct {
 
 	// Non-Discord property
 	// The messages in the channel. This is only present in state-cached channels,
-	// and State.MaxMessageCount must be non-zero.
+	// and State.MaxMessageCount must be non-zero. Messages cached in
+	// State.MessageCache aren't kept here.
 	Messages []*Message `json:"-"`
 }
 
@@ -313,6 +330,11 @@ func (c *Channel) Mention() string {
 	return fmt.Sprintf("<#%s>", c.ID)
 }
 
+// IsThread returns true if the channel is a thread.
+func (c *Channel) IsThread() bool {
+	return c.Type == ChannelTypeGuildNewsThread || c.Type == ChannelTypeGuildPublicThread || c.Type == ChannelTypeGuildPrivateThread
+}
+
 // A ChannelEdit holds Channel Field data for a channel edit.
 type ChannelEdit struct {
 	Name                 string                 `json:"name,omitempty"`
@@ -342,6 +364,15 @@ type ThreadMember struct {
 	Flags         int       `json:"flags"`
 }
 
+// A ThreadsList holds a list of threads and the thread members of the current user.
+type ThreadsList struct {
+	Threads []*Channel      `json:"threads"`
+	Members []*ThreadMember `json:"members"`
+
+	// Whether there are more archived threads, only set for archived threads.
+	HasMore bool `json:"has_more"`
+}
+
 // FollowChannel structure holds information from ChannelFollow function
 type FollowChannel struct {
 	ChannelID string `json:"channel_id"`
@@ -352,8 +383,8 @@ type FollowChannel struct {
 type PermissionOverwrite struct {
 	ID    string                  `json:"id"`
 	Type  PermissionOverwriteType `json:"type"`
-	Deny  int                     `json:"deny,string"`
-	Allow int                     `json:"allow,string"`
+	Deny  Permissions             `json:"deny"`
+	Allow Permissions             `json:"allow"`
 }
 
 type PermissionOverwriteType int
@@ -633,11 +664,11 @@ func (g *Guild) IconURL() string {
 
 // A UserGuild holds a brief version of a Guild
 type UserGuild struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Icon        string `json:"icon"`
-	Owner       bool   `json:"owner"`
-	Permissions int    `json:"permissions,string"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Icon        string      `json:"icon"`
+	Owner       bool        `json:"owner"`
+	Permissions Permissions `json:"permissions"`
 }
 
 // A GuildParams stores all the data needed to update discord guild settings
@@ -691,9 +722,8 @@ type Role struct {
 	Position int `json:"position"`
 
 	// The permissions of the role on the guild (doesn't include channel overrides).
-	// This is a combination of bit masks; the presence of a certain permission can
-	// be checked by performing a bitwise AND between this int and the permission.
-	Permissions int `json:"permissions,string"`
+	// The presence of a certain permission can be checked with Permissions.Has.
+	Permissions Permissions `json:"permissions"`
 
 	// Whether this role is managed by an integration, and
 	// thus cannot be manually added to, or taken from, members.
@@ -716,7 +746,7 @@ func (r Roles) Len() int {
 }
 
 func (r Roles) Less(i, j int) bool {
-	return r[i].Position > r[j].Position
+	return roleAbove(r[i], r[j])
 }
 
 func (r Roles) Swap(i, j int) {
@@ -858,6 +888,9 @@ type Member struct {
 	// Whether the member is muted at a guild level.
 	Mute bool `json:"mute"`
 
+	// When the timeout of the member ends, empty if they aren't timed out.
+	CommunicationDisabledUntil Timestamp `json:"communication_disabled_until"`
+
 	// The guild ID on which the member exists.
 	// This property is not provided by Discord. I only left it here to not break the state.go
 	GuildID string `json:"guild_id"`
@@ -868,6 +901,16 @@ func (m *Member) Mention() string {
 	return "<@!" + m.User.ID + ">"
 }
 
+// TimedOut returns true if the member is currently timed out.
+func (m *Member) TimedOut() bool {
+	if m.CommunicationDisabledUntil == "" {
+		return false
+	}
+
+	until, err := m.CommunicationDisabledUntil.Parse()
+	return err == nil && until.After(time.Now())
+}
+
 // A Settings stores data for a specific users Discord client settings.
 type Settings struct {
 	RenderEmbeds           bool               `json:"render_embeds"`
@@ -1087,9 +1130,23 @@ type WebhookParams struct {
 	Username        string          `json:"username,omitempty"`
 	AvatarURL       string          `json:"avatar_url,omitempty"`
 	TTS             bool            `json:"tts,omitempty"`
-	File            string          `json:"file,omitempty"`
+	Files           []*File         `json:"-"`
 	Embeds          []*MessageEmbed `json:"embeds,omitempty"`
 	AllowedMentions AllowMention    `json:"allowed_mentions"`
+	Flags           int             `json:"flags,omitempty"`
+}
+
+// WebhookEdit stores data for editing of a webhook message.
+type WebhookEdit struct {
+	Content         *string          `json:"content,omitempty"`
+	Embeds          *[]*MessageEmbed `json:"embeds,omitempty"`
+	Components      *[]Component     `json:"components,omitempty"`
+	AllowedMentions *AllowMention    `json:"allowed_mentions,omitempty"`
+
+	// Files are added to the message.
+	Files []*File `json:"-"`
+	// Attachments are the existing attachments to keep, if set all others are removed.
+	Attachments *[]*MessageAttachment `json:"attachments,omitempty"`
 }
 
 type AllowMention struct {
@@ -1137,6 +1194,7 @@ const (
 	StickerFormatTypePNG StickerFormatType = iota + 1
 	StickerFormatTypeAPNG
 	StickerFormatTypeLOTTIE
+	StickerFormatTypeGIF
 )
 
 type Component struct {
@@ -1302,9 +1360,12 @@ type InteractionApplicationCommandCallbackData struct {
 	TTS             bool            `json:"tts,omitempty"`
 	Content         string          `json:"content"`
 	Embeds          *[]MessageEmbed `json:"embeds,omitempty"`
-	AllowedMentions *AllowMention   `json:"allowed_mentions,omitempty"` // TODO: Fix AllowMention in the fork
+	AllowedMentions *AllowMention   `json:"allowed_mentions,omitempty"`
 	Flags           int             `json:"flags,omitempty"`
 	Components      *[]Component    `json:"components,omitempty"` // I think this should be here ~MBSA
+
+	Files       []*File               `json:"-"`
+	Attachments *[]*MessageAttachment `json:"attachments,omitempty"`
 }
 
 type InteractionMessage struct {
@@ -1316,7 +1377,7 @@ type InteractionMessage struct {
 
 // Constants for the different bit offsets of text channel permissions
 const (
-	PermissionReadMessages = 1 << (iota + 10)
+	PermissionReadMessages Permissions = 1 << (iota + 10)
 	PermissionSendMessages
 	PermissionSendTTSMessages
 	PermissionManageMessages
@@ -1325,31 +1386,54 @@ const (
 	PermissionReadMessageHistory
 	PermissionMentionEveryone
 	PermissionUseExternalEmojis
+	PermissionViewGuildInsights
+
+	// PermissionViewChannel is the name Discord uses for PermissionReadMessages.
+	PermissionViewChannel = PermissionReadMessages
 )
 
 // Constants for the different bit offsets of voice permissions
 const (
-	PermissionVoiceConnect = 1 << (iota + 20)
+	PermissionVoiceConnect Permissions = 1 << (iota + 20)
 	PermissionVoiceSpeak
 	PermissionVoiceMuteMembers
 	PermissionVoiceDeafenMembers
 	PermissionVoiceMoveMembers
 	PermissionVoiceUseVAD
-	PermissionVoicePrioritySpeaker = 1 << (iota + 2)
+	PermissionVoicePrioritySpeaker Permissions = 1 << (iota + 2)
+	PermissionVoiceStreamVideo
 )
 
 // Constants for general management.
 const (
-	PermissionChangeNickname = 1 << (iota + 26)
+	PermissionChangeNickname Permissions = 1 << (iota + 26)
 	PermissionManageNicknames
 	PermissionManageRoles
 	PermissionManageWebhooks
 	PermissionManageEmojis
+	PermissionUseApplicationCommands
+	PermissionVoiceRequestToSpeak
+	PermissionManageEvents
+	PermissionManageThreads
+	PermissionCreatePublicThreads
+	PermissionCreatePrivateThreads
+	PermissionUseExternalStickers
+	PermissionSendMessagesInThreads
+	PermissionUseEmbeddedActivities
+	PermissionModerateMembers
+	PermissionViewCreatorMonetizationAnalytics
+	PermissionUseSoundboard
+	PermissionCreateGuildExpressions
+	PermissionCreateEvents
+	PermissionUseExternalSounds
+	PermissionSendVoiceMessages
+	PermissionSendPolls Permissions = 1 << (iota + 28)
+	PermissionUseExternalApps
 )
 
 // Constants for the different bit offsets of general permissions
 const (
-	PermissionCreateInstantInvite = 1 << iota
+	PermissionCreateInstantInvite Permissions = 1 << iota
 	PermissionKickMembers
 	PermissionBanMembers
 	PermissionAdministrator
@@ -1365,28 +1449,51 @@ const (
 		PermissionEmbedLinks |
 		PermissionAttachFiles |
 		PermissionReadMessageHistory |
-		PermissionMentionEveryone
+		PermissionMentionEveryone |
+		PermissionUseExternalEmojis |
+		PermissionUseApplicationCommands |
+		PermissionManageThreads |
+		PermissionCreatePublicThreads |
+		PermissionCreatePrivateThreads |
+		PermissionUseExternalStickers |
+		PermissionSendMessagesInThreads |
+		PermissionSendVoiceMessages |
+		PermissionSendPolls |
+		PermissionUseExternalApps
 	PermissionAllVoice = PermissionVoiceConnect |
 		PermissionVoiceSpeak |
 		PermissionVoiceMuteMembers |
 		PermissionVoiceDeafenMembers |
 		PermissionVoiceMoveMembers |
 		PermissionVoiceUseVAD |
-		PermissionVoicePrioritySpeaker
+		PermissionVoicePrioritySpeaker |
+		PermissionVoiceStreamVideo |
+		PermissionVoiceRequestToSpeak |
+		PermissionUseEmbeddedActivities |
+		PermissionUseSoundboard |
+		PermissionUseExternalSounds
 	PermissionAllChannel = PermissionAllText |
 		PermissionAllVoice |
 		PermissionCreateInstantInvite |
 		PermissionManageRoles |
 		PermissionManageChannels |
 		PermissionAddReactions |
-		PermissionViewAuditLogs
+		PermissionViewAuditLogs |
+		PermissionManageWebhooks |
+		PermissionManageEvents |
+		PermissionCreateEvents
 	PermissionAll = PermissionAllChannel |
 		PermissionKickMembers |
 		PermissionBanMembers |
 		PermissionManageServer |
 		PermissionAdministrator |
-		PermissionManageWebhooks |
-		PermissionManageEmojis
+		PermissionManageEmojis |
+		PermissionChangeNickname |
+		PermissionManageNicknames |
+		PermissionViewGuildInsights |
+		PermissionModerateMembers |
+		PermissionViewCreatorMonetizationAnalytics |
+		PermissionCreateGuildExpressions
 )
 
 // Block contains Discord JSON Error Response codes
@@ -1442,3 +1549,129 @@ const (
 
 	ErrCodeReactionBlocked = 90001
 )
+
+// SlackWebhookParams is a Slack compatible webhook payload, used in the WebhookExecuteSlack command.
+type SlackWebhookParams struct {
+	Text        string             `json:"text,omitempty"`
+	Username    string             `json:"username,omitempty"`
+	IconURL     string             `json:"icon_url,omitempty"`
+	Attachments []*SlackAttachment `json:"attachments,omitempty"`
+}
+
+// SlackAttachment is an attachment of a Slack webhook payload, Discord shows it as an embed.
+type SlackAttachment struct {
+	Fallback   string                  `json:"fallback,omitempty"`
+	Color      string                  `json:"color,omitempty"`
+	Pretext    string                  `json:"pretext,omitempty"`
+	AuthorName string                  `json:"author_name,omitempty"`
+	AuthorLink string                  `json:"author_link,omitempty"`
+	AuthorIcon string                  `json:"author_icon,omitempty"`
+	Title      string                  `json:"title,omitempty"`
+	TitleLink  string                  `json:"title_link,omitempty"`
+	Text       string                  `json:"text,omitempty"`
+	Fields     []*SlackAttachmentField `json:"fields,omitempty"`
+	ImageURL   string                  `json:"image_url,omitempty"`
+	ThumbURL   string                  `json:"thumb_url,omitempty"`
+	Footer     string                  `json:"footer,omitempty"`
+	FooterIcon string                  `json:"footer_icon,omitempty"`
+	// Ts is a Unix timestamp shown as the time of the attachment.
+	Ts int64 `json:"ts,omitempty"`
+}
+
+// SlackAttachmentField is a field of a SlackAttachment.
+type SlackAttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short,omitempty"`
+}
+
+// GitHubWebhookPayload is the part of a GitHub webhook payload which Discord
+// uses to build its messages, used in the WebhookExecuteGitHub command.
+type GitHubWebhookPayload struct {
+	Action     string            `json:"action,omitempty"`
+	Sender     *GitHubUser       `json:"sender,omitempty"`
+	Repository *GitHubRepository `json:"repository,omitempty"`
+
+	// push, create and delete events
+	Ref        string          `json:"ref,omitempty"`
+	RefType    string          `json:"ref_type,omitempty"`
+	Before     string          `json:"before,omitempty"`
+	After      string          `json:"after,omitempty"`
+	Compare    string          `json:"compare,omitempty"`
+	Forced     bool            `json:"forced,omitempty"`
+	Commits    []*GitHubCommit `json:"commits,omitempty"`
+	HeadCommit *GitHubCommit   `json:"head_commit,omitempty"`
+
+	// pull_request, issues, *_comment, release and fork events
+	PullRequest *GitHubIssue      `json:"pull_request,omitempty"`
+	Issue       *GitHubIssue      `json:"issue,omitempty"`
+	Comment     *GitHubComment    `json:"comment,omitempty"`
+	Release     *GitHubRelease    `json:"release,omitempty"`
+	Forkee      *GitHubRepository `json:"forkee,omitempty"`
+}
+
+// GitHubUser is a GitHub user of a GitHubWebhookPayload.
+type GitHubUser struct {
+	Login     string `json:"login"`
+	ID        int64  `json:"id,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+	HTMLURL   string `json:"html_url,omitempty"`
+}
+
+// GitHubRepository is a GitHub repository of a GitHubWebhookPayload.
+type GitHubRepository struct {
+	ID       int64       `json:"id,omitempty"`
+	Name     string      `json:"name"`
+	FullName string      `json:"full_name"`
+	HTMLURL  string      `json:"html_url,omitempty"`
+	Private  bool        `json:"private,omitempty"`
+	Owner    *GitHubUser `json:"owner,omitempty"`
+}
+
+// GitHubCommit is a commit of a push GitHubWebhookPayload.
+type GitHubCommit struct {
+	ID      string              `json:"id"`
+	Message string              `json:"message"`
+	URL     string              `json:"url,omitempty"`
+	Author  *GitHubCommitAuthor `json:"author,omitempty"`
+}
+
+// GitHubCommitAuthor is the author of a GitHubCommit.
+type GitHubCommitAuthor struct {
+	Name     string `json:"name"`
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// GitHubIssue is an issue or pull request of a GitHubWebhookPayload.
+type GitHubIssue struct {
+	ID      int64       `json:"id,omitempty"`
+	Number  int         `json:"number"`
+	Title   string      `json:"title"`
+	Body    string      `json:"body,omitempty"`
+	State   string      `json:"state,omitempty"`
+	HTMLURL string      `json:"html_url,omitempty"`
+	User    *GitHubUser `json:"user,omitempty"`
+	Merged  bool        `json:"merged,omitempty"`
+}
+
+// GitHubComment is a comment of a GitHubWebhookPayload.
+type GitHubComment struct {
+	ID       int64       `json:"id,omitempty"`
+	Body     string      `json:"body"`
+	HTMLURL  string      `json:"html_url,omitempty"`
+	User     *GitHubUser `json:"user,omitempty"`
+	CommitID string      `json:"commit_id,omitempty"`
+}
+
+// GitHubRelease is a release of a GitHubWebhookPayload.
+type GitHubRelease struct {
+	ID         int64       `json:"id,omitempty"`
+	TagName    string      `json:"tag_name"`
+	Name       string      `json:"name,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	HTMLURL    string      `json:"html_url,omitempty"`
+	Draft      bool        `json:"draft,omitempty"`
+	Prerelease bool        `json:"prerelease,omitempty"`
+	Author     *GitHubUser `json:"author,omitempty"`
+}
@@ -0,0 +1,249 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the HTML transcript writer.
+
+package transcript
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/NilPointer-Software/discordgo"
+)
+
+// HTMLWriter writes a transcript as a single HTML page with embedded styles.
+// Images of attachments, embeds and avatars are linked, not embedded.
+type HTMLWriter struct {
+	w     *bufio.Writer
+	depth int
+
+	// authors of the written messages by message ID, to show replies.
+	authors map[string]string
+}
+
+// NewHTMLWriter returns an HTMLWriter writing to w.
+func NewHTMLWriter(w io.Writer) *HTMLWriter {
+	return &HTMLWriter{w: bufio.NewWriter(w), authors: make(map[string]string)}
+}
+
+// StartChannel implements Writer.
+func (h *HTMLWriter) StartChannel(c *Channel) error {
+	name := "header"
+	if h.depth > 0 {
+		name = "thread"
+	}
+	h.depth++
+	return htmlTemplates.ExecuteTemplate(h.w, name, c)
+}
+
+// WriteMessage implements Writer.
+func (h *HTMLWriter) WriteMessage(m *Message) error {
+	if h.depth == 0 {
+		return ErrNoChannel
+	}
+
+	data := struct {
+		*Message
+		ReplyTo string
+	}{Message: m}
+	if m.Reference != nil {
+		data.ReplyTo = h.authors[m.Reference.MessageID]
+	}
+	h.authors[m.ID] = m.Author.DisplayName()
+
+	return htmlTemplates.ExecuteTemplate(h.w, "message", data)
+}
+
+// EndChannel implements Writer. Ending the exported channel completes the
+// page and flushes it.
+func (h *HTMLWriter) EndChannel() error {
+	if h.depth == 0 {
+		return ErrNoChannel
+	}
+
+	h.depth--
+	if h.depth > 0 {
+		_, err := h.w.WriteString("</section>\n")
+		return err
+	}
+
+	if err := htmlTemplates.ExecuteTemplate(h.w, "footer", time.Now().UTC()); err != nil {
+		return err
+	}
+	return h.w.Flush()
+}
+
+var htmlTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"content": renderContent,
+	"markdown": func(text string) template.HTML {
+		return renderContent(&Message{Content: text})
+	},
+	"time": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04")
+	},
+	"color": func(color int) template.CSS {
+		return template.CSS(fmt.Sprintf("#%06x", color))
+	},
+	"size": formatSize,
+	"image": func(contentType string) bool {
+		return strings.HasPrefix(contentType, "image/")
+	},
+	"emoji": func(e Emoji) template.HTML {
+		if e.ID == "" {
+			return template.HTML(template.HTMLEscapeString(e.Name))
+		}
+		URL, _ := discordgo.CDNEmoji(e.ID, e.Animated, "", 0)
+		return template.HTML(fmt.Sprintf(`<img class="emoji" src="%s" alt=":%s:">`,
+			template.HTMLEscapeString(URL), template.HTMLEscapeString(e.Name)))
+	},
+}).Parse(htmlTemplate))
+
+// formatSize formats a file size in bytes.
+func formatSize(size int) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.2f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.2f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d bytes", size)
+	}
+}
+
+const htmlTemplate = `
+{{- define "header" -}}
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>#{{.Name}}</title>
+<style>
+body { margin: 0; background: #36393f; color: #dcddde; font: 15px/1.4 "Helvetica Neue", Helvetica, Arial, sans-serif; }
+header, footer { padding: 16px 24px; background: #2f3136; }
+header h1 { margin: 0; font-size: 20px; color: #fff; }
+footer { color: #72767d; font-size: 12px; }
+main { padding: 8px 0; }
+a { color: #00aff4; }
+.message { display: flex; padding: 4px 24px; }
+.message:hover { background: #32353b; }
+.avatar { width: 40px; height: 40px; border-radius: 50%; margin-right: 16px; flex-shrink: 0; }
+.body { min-width: 0; }
+.author { color: #fff; font-weight: 500; }
+.bot { background: #5865f2; color: #fff; font-size: 10px; padding: 1px 4px; border-radius: 3px; margin-left: 4px; }
+.timestamp, .edited, .reply { color: #72767d; font-size: 12px; }
+.reply a { color: #b9bbbe; }
+.content { word-wrap: break-word; }
+.mention { background: rgba(88, 101, 242, .3); color: #dee0fc; border-radius: 3px; padding: 0 2px; }
+.spoiler { background: #202225; color: transparent; border-radius: 3px; }
+.spoiler:hover { color: inherit; }
+code { background: #2f3136; border-radius: 3px; padding: 0 2px; font-family: Consolas, monospace; }
+pre code { display: block; padding: 8px; border: 1px solid #202225; white-space: pre-wrap; }
+blockquote { margin: 0; padding-left: 8px; border-left: 4px solid #4f545c; }
+.emoji { width: 22px; height: 22px; vertical-align: bottom; }
+.attachment { margin-top: 4px; }
+.attachment img { max-width: 400px; max-height: 300px; border-radius: 3px; }
+.embed { margin-top: 4px; max-width: 520px; padding: 8px 16px; background: #2f3136; border-left: 4px solid #202225; border-radius: 4px; }
+.embed-title { color: #fff; font-weight: 600; }
+.embed-fields { display: flex; flex-wrap: wrap; }
+.embed-field { min-width: 100%; margin-top: 8px; }
+.embed-field.inline { min-width: 150px; flex: 1; }
+.embed-field-name { color: #fff; font-weight: 600; }
+.embed img.image { max-width: 100%; margin-top: 8px; border-radius: 4px; }
+.embed img.thumbnail { float: right; max-width: 80px; max-height: 80px; margin-left: 16px; border-radius: 4px; }
+.embed-footer { margin-top: 8px; font-size: 12px; }
+.reactions { margin-top: 4px; }
+.reaction { display: inline-block; background: #2f3136; border-radius: 8px; padding: 0 6px; margin-right: 4px; }
+.thread { margin: 8px 24px 8px 80px; border-left: 2px solid #4f545c; }
+.thread h2 { margin: 0; padding: 4px 24px; font-size: 16px; color: #fff; }
+</style>
+</head>
+<body>
+<header>
+<h1>#{{.Name}}</h1>
+{{- if .Topic}}
+<p>{{markdown .Topic}}</p>
+{{- end}}
+</header>
+<main>
+{{end -}}
+
+{{- define "thread" -}}
+<section class="thread" id="thread-{{.ID}}">
+<h2>{{.Name}}</h2>
+{{end -}}
+
+{{- define "message" -}}
+<div class="message" id="message-{{.ID}}">
+<img class="avatar" src="{{.Author.AvatarURL}}" alt="">
+<div class="body">
+{{- if .Reference}}
+<div class="reply"><a href="#message-{{.Reference.MessageID}}">replying to {{if .ReplyTo}}@{{.ReplyTo}}{{else}}a message{{end}}</a></div>
+{{- end}}
+<div><span class="author" title="{{.Author.Username}}#{{.Author.Discriminator}}">{{.Author.DisplayName}}</span>
+{{- if .Author.Bot}}<span class="bot">BOT</span>{{end}}
+<time class="timestamp" datetime="{{.Timestamp.Format "2006-01-02T15:04:05Z07:00"}}">{{time .Timestamp}}</time>
+{{- if .EditedTimestamp}} <span class="edited" title="{{time .EditedTimestamp}}">(edited)</span>{{end}}</div>
+{{- if .Content}}
+<div class="content">{{content .Message}}</div>
+{{- end}}
+{{- range .Attachments}}
+<div class="attachment">
+{{- if image .ContentType}}
+<a href="{{.URL}}"><img src="{{.URL}}" alt="{{if .Description}}{{.Description}}{{else}}{{.Filename}}{{end}}"></a>
+{{- else}}
+<a href="{{.URL}}">{{.Filename}}</a> ({{size .Size}})
+{{- end}}
+</div>
+{{- end}}
+{{- range .Embeds}}
+<div class="embed"{{if .Color}} style="border-left-color: {{color .Color}}"{{end}}>
+{{- if .ThumbnailURL}}<img class="thumbnail" src="{{.ThumbnailURL}}" alt="">{{end}}
+{{- with .Author}}
+<div class="embed-author">{{if .URL}}<a href="{{.URL}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</div>
+{{- end}}
+{{- if .Title}}
+<div class="embed-title">{{if .URL}}<a href="{{.URL}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</div>
+{{- end}}
+{{- if .Description}}
+<div class="content">{{markdown .Description}}</div>
+{{- end}}
+{{- if .Fields}}
+<div class="embed-fields">
+{{- range .Fields}}
+<div class="embed-field{{if .Inline}} inline{{end}}"><div class="embed-field-name">{{markdown .Name}}</div><div class="content">{{markdown .Value}}</div></div>
+{{- end}}
+</div>
+{{- end}}
+{{- if .ImageURL}}
+<img class="image" src="{{.ImageURL}}" alt="">
+{{- end}}
+{{- if or .Footer .Timestamp}}
+<div class="embed-footer">{{with .Footer}}{{.Text}}{{end}}{{if and .Footer .Timestamp}} • {{end}}{{.Timestamp}}</div>
+{{- end}}
+</div>
+{{- end}}
+{{- if .Reactions}}
+<div class="reactions">
+{{- range .Reactions}}<span class="reaction">{{emoji .Emoji}} {{.Count}}</span>{{end -}}
+</div>
+{{- end}}
+</div>
+</div>
+{{end -}}
+
+{{- define "footer" -}}
+</main>
+<footer>Exported at {{time .}} UTC</footer>
+</body>
+</html>
+{{end -}}
+`
@@ -0,0 +1,127 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the JSON transcript writer.
+
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNoChannel is returned when a message is written or a channel is ended
+// before a channel was started.
+var ErrNoChannel = errors.New("no channel was started")
+
+// JSONWriter writes a transcript as a JSON document:
+//
+//	{
+//	  "version": 1,
+//	  "exported_at": "2021-06-01T12:00:00Z",
+//	  "channel": {
+//	    "id": "...", "name": "...", ...,
+//	    "messages": [...],
+//	    "threads": [{"id": "...", ..., "messages": [...]}]
+//	  }
+//	}
+//
+// Messages are written as soon as they are received.
+type JSONWriter struct {
+	w *bufio.Writer
+
+	// open holds for each started channel whether something was written to its current list.
+	open    []bool
+	threads bool
+}
+
+// NewJSONWriter returns a JSONWriter writing to w.
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{w: bufio.NewWriter(w)}
+}
+
+// StartChannel implements Writer.
+func (j *JSONWriter) StartChannel(c *Channel) error {
+	switch len(j.open) {
+	case 0:
+		header, err := json.Marshal(struct {
+			Version    int       `json:"version"`
+			ExportedAt time.Time `json:"exported_at"`
+		}{Version, time.Now().UTC()})
+		if err != nil {
+			return err
+		}
+		j.w.Write(header[:len(header)-1])
+		j.w.WriteString(`,"channel":`)
+	case 1:
+		if !j.threads {
+			j.w.WriteString(`],"threads":[`)
+			j.threads = true
+		} else {
+			j.w.WriteString(",")
+		}
+	default:
+		return errors.New("threads can't have threads")
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	j.w.Write(b[:len(b)-1])
+	j.w.WriteString(`,"messages":[`)
+	j.open = append(j.open, false)
+	return nil
+}
+
+// WriteMessage implements Writer.
+func (j *JSONWriter) WriteMessage(m *Message) error {
+	if len(j.open) == 0 {
+		return ErrNoChannel
+	}
+	if len(j.open) == 1 && j.threads {
+		return errors.New("messages of a channel have to be written before its threads")
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	if j.open[len(j.open)-1] {
+		j.w.WriteString(",")
+	}
+	j.open[len(j.open)-1] = true
+	_, err = j.w.Write(b)
+	return err
+}
+
+// EndChannel implements Writer. Ending the exported channel completes the
+// document and flushes it.
+func (j *JSONWriter) EndChannel() error {
+	switch len(j.open) {
+	case 0:
+		return ErrNoChannel
+	case 1:
+		if !j.threads {
+			j.w.WriteString(`],"threads":[`)
+		}
+		j.w.WriteString("]}}\n")
+	default:
+		j.w.WriteString("]}")
+	}
+
+	j.open = j.open[:len(j.open)-1]
+	if len(j.open) == 0 {
+		j.threads = false
+		return j.w.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the rendering of message content to HTML.
+
+package transcript
+
+import (
+	"html/template"
+
+	"github.com/NilPointer-Software/discordgo/markdown"
+)
+
+// renderContent renders the content of a message as HTML.
+func renderContent(m *Message) template.HTML {
+	return template.HTML(markdown.RenderHTML(markdown.Parse(m.Content), messageResolver{m}))
+}
+
+// messageResolver is a markdown.Resolver which resolves the mentions of an exported message.
+type messageResolver struct {
+	m *Message
+}
+
+func (r messageResolver) User(id string) string {
+	for _, u := range r.m.Mentions {
+		if u.ID == id {
+			return u.DisplayName()
+		}
+	}
+	return ""
+}
+
+func (r messageResolver) Role(id string) string {
+	for _, role := range r.m.MentionRoles {
+		if role.ID == id {
+			return role.Name
+		}
+	}
+	return ""
+}
+
+func (r messageResolver) Channel(id string) string {
+	for _, c := range r.m.MentionChannels {
+		if c.ID == id {
+			return c.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains the types of the transcript schema. They are
+// independent of the discordgo types, so transcripts stay readable when
+// those change.
+
+package transcript
+
+import "time"
+
+// A Channel is an exported channel or thread.
+type Channel struct {
+	ID       string `json:"id"`
+	GuildID  string `json:"guild_id,omitempty"`
+	ParentID string `json:"parent_id,omitempty"`
+	Name     string `json:"name"`
+	Topic    string `json:"topic,omitempty"`
+	Type     int    `json:"type"`
+}
+
+// A User is the author of a message or a mentioned user.
+type User struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	Discriminator string `json:"discriminator"`
+	Nickname      string `json:"nickname,omitempty"`
+	AvatarURL     string `json:"avatar_url"`
+	Bot           bool   `json:"bot,omitempty"`
+}
+
+// DisplayName returns the nickname of the user, or the username if it has none.
+func (u *User) DisplayName() string {
+	if u.Nickname != "" {
+		return u.Nickname
+	}
+	return u.Username
+}
+
+// A Role is a mentioned role. Name is empty if the role couldn't be resolved.
+type Role struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Color int    `json:"color,omitempty"`
+}
+
+// A Message is an exported message.
+type Message struct {
+	ID              string     `json:"id"`
+	Type            int        `json:"type"`
+	Author          User       `json:"author"`
+	Content         string     `json:"content"`
+	Timestamp       time.Time  `json:"timestamp"`
+	EditedTimestamp *time.Time `json:"edited_timestamp,omitempty"`
+	Pinned          bool       `json:"pinned,omitempty"`
+
+	// The mentioned users, roles and channels, resolved when the message was exported.
+	Mentions        []User    `json:"mentions"`
+	MentionRoles    []Role    `json:"mention_roles"`
+	MentionChannels []Channel `json:"mention_channels"`
+
+	Attachments []Attachment `json:"attachments"`
+	Embeds      []Embed      `json:"embeds"`
+	Reactions   []Reaction   `json:"reactions"`
+
+	// Reference is the message this message replies to.
+	Reference *Reference `json:"reference,omitempty"`
+}
+
+// An Attachment holds the metadata of an attached file.
+type Attachment struct {
+	ID          string `json:"id"`
+	Filename    string `json:"filename"`
+	Description string `json:"description,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int    `json:"size"`
+	URL         string `json:"url"`
+	ProxyURL    string `json:"proxy_url,omitempty"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+}
+
+// An Embed is a rich embed of a message.
+type Embed struct {
+	Title        string       `json:"title,omitempty"`
+	Description  string       `json:"description,omitempty"`
+	URL          string       `json:"url,omitempty"`
+	Timestamp    string       `json:"timestamp,omitempty"`
+	Color        int          `json:"color,omitempty"`
+	Author       *EmbedAuthor `json:"author,omitempty"`
+	Footer       *EmbedFooter `json:"footer,omitempty"`
+	ImageURL     string       `json:"image_url,omitempty"`
+	ThumbnailURL string       `json:"thumbnail_url,omitempty"`
+	Fields       []EmbedField `json:"fields"`
+}
+
+// An EmbedAuthor is the author of an Embed.
+type EmbedAuthor struct {
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+// An EmbedFooter is the footer of an Embed.
+type EmbedFooter struct {
+	Text    string `json:"text"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+// An EmbedField is a field of an Embed.
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// A Reaction is the number of reactions with an emoji.
+type Reaction struct {
+	Emoji Emoji `json:"emoji"`
+	Count int   `json:"count"`
+}
+
+// An Emoji is a unicode emoji if ID is empty, or a custom emoji.
+type Emoji struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Animated bool   `json:"animated,omitempty"`
+}
+
+// A Reference points to the message a message replies to.
+type Reference struct {
+	MessageID string `json:"message_id"`
+	ChannelID string `json:"channel_id,omitempty"`
+	GuildID   string `json:"guild_id,omitempty"`
+}
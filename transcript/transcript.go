@@ -0,0 +1,334 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package transcript exports the history of Discord channels, including
+// their threads, as JSON documents or self-contained HTML pages.
+//
+// Messages are streamed to a Writer while they are requested, so channels
+// of any size can be exported:
+//
+//	f, _ := os.Create("ticket.html")
+//	err := transcript.Export(s, channelID, transcript.NewHTMLWriter(f), nil)
+package transcript
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/NilPointer-Software/discordgo"
+)
+
+// Version is the version of the JSON schema written by JSONWriter.
+// It is only increased for changes which aren't backwards compatible.
+const Version = 1
+
+// A Writer writes a transcript. Export calls StartChannel for the exported
+// channel, WriteMessage for each of its messages and then StartChannel,
+// WriteMessage and EndChannel for each thread, before the channel itself is
+// ended. The transcript is complete when the exported channel is ended.
+type Writer interface {
+	StartChannel(c *Channel) error
+	WriteMessage(m *Message) error
+	EndChannel() error
+}
+
+// Options configure Export.
+type Options struct {
+	// Threads exports the active and archived threads of the channel.
+	Threads bool
+
+	// Since and Until limit the exported messages, they are ignored if zero.
+	Since time.Time
+	Until time.Time
+
+	// RequestOptions are passed to all REST requests.
+	RequestOptions []discordgo.RequestOption
+}
+
+// Export requests the history of a channel and writes it to w, oldest message first.
+// Mentions are resolved through the State of the session when it is enabled.
+// s         : The session used to request the messages.
+// channelID : The ID of the Channel to export.
+// w         : The Writer the transcript is written to.
+// opts      : Optional options, may be nil.
+func Export(s *discordgo.Session, channelID string, w Writer, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	channel, err := s.Channel(channelID, opts.RequestOptions...)
+	if err != nil {
+		return err
+	}
+
+	e := &exporter{s: s, w: w, opts: opts}
+	if err = e.channel(channel); err != nil {
+		return err
+	}
+
+	if opts.Threads {
+		threads, err := e.threads(channel)
+		if err != nil {
+			return err
+		}
+
+		for _, thread := range threads {
+			if err = e.channel(thread); err != nil {
+				return err
+			}
+			if err = w.EndChannel(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.EndChannel()
+}
+
+type exporter struct {
+	s    *discordgo.Session
+	w    Writer
+	opts *Options
+}
+
+// channel starts a channel and writes its messages.
+func (e *exporter) channel(channel *discordgo.Channel) error {
+	if err := e.w.StartChannel(newChannel(channel)); err != nil {
+		return err
+	}
+
+	it := e.s.ChannelMessagesIterator(channel.ID, discordgo.IteratorOptions{
+		Direction: discordgo.IterateForward,
+		Since:     e.opts.Since,
+		Until:     e.opts.Until,
+	}, e.opts.RequestOptions...)
+
+	for it.Next() {
+		if err := e.w.WriteMessage(e.message(channel, it.Message())); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// threads returns the threads of a channel, oldest first.
+func (e *exporter) threads(channel *discordgo.Channel) ([]*discordgo.Channel, error) {
+	var threads []*discordgo.Channel
+	seen := make(map[string]bool)
+	add := func(list []*discordgo.Channel) {
+		for _, t := range list {
+			if t.ParentID == channel.ID && !seen[t.ID] {
+				seen[t.ID] = true
+				threads = append(threads, t)
+			}
+		}
+	}
+
+	active, err := e.s.ChannelThreadsActive(channel.ID, e.opts.RequestOptions...)
+	if err != nil {
+		return nil, err
+	}
+	add(active.Threads)
+
+	for _, private := range []bool{false, true} {
+		var before time.Time
+		for {
+			archived, err := e.s.ChannelThreadsArchived(channel.ID, private, before, 100, e.opts.RequestOptions...)
+			if err != nil {
+				if private && isForbidden(err) {
+					// Private threads need the Manage Threads permission.
+					break
+				}
+				return nil, err
+			}
+			add(archived.Threads)
+
+			if !archived.HasMore || len(archived.Threads) == 0 {
+				break
+			}
+			last := archived.Threads[len(archived.Threads)-1]
+			if last.ThreadMetadata == nil {
+				break
+			}
+			if before, err = last.ThreadMetadata.ArchiveTimestamp.Parse(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(threads, func(i, j int) bool {
+		return discordgo.SnowflakeLess(threads[i].ID, threads[j].ID)
+	})
+	return threads, nil
+}
+
+func isForbidden(err error) bool {
+	restErr, ok := err.(*discordgo.RESTError)
+	return ok && restErr.Response != nil && restErr.Response.StatusCode == 403
+}
+
+var (
+	patternChannelMention = regexp.MustCompile(`<#(\d+)>`)
+	patternRoleMention    = regexp.MustCompile(`<@&(\d+)>`)
+)
+
+// message converts a message and resolves its mentions.
+func (e *exporter) message(channel *discordgo.Channel, m *discordgo.Message) *Message {
+	guildID := channel.GuildID
+	msg := &Message{
+		ID:              m.ID,
+		Type:            int(m.Type),
+		Content:         m.Content,
+		Pinned:          m.Pinned,
+		Mentions:        []User{},
+		MentionRoles:    []Role{},
+		MentionChannels: []Channel{},
+		Attachments:     []Attachment{},
+		Embeds:          []Embed{},
+		Reactions:       []Reaction{},
+	}
+
+	if m.Author != nil {
+		msg.Author = e.user(guildID, m.Author, m.Member)
+	}
+	msg.Timestamp, _ = m.Timestamp.Parse()
+	if edited, err := m.EditedTimestamp.Parse(); err == nil {
+		msg.EditedTimestamp = &edited
+	}
+
+	for _, u := range m.Mentions {
+		msg.Mentions = append(msg.Mentions, e.user(guildID, u, nil))
+	}
+
+	roleIDs := append([]string{}, m.MentionRoles...)
+	for _, match := range patternRoleMention.FindAllStringSubmatch(m.Content, -1) {
+		roleIDs = append(roleIDs, match[1])
+	}
+	seen := make(map[string]bool)
+	for _, id := range roleIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		role := Role{ID: id}
+		if e.s.StateEnabled && e.s.State != nil {
+			if r, err := e.s.State.Role(guildID, id); err == nil {
+				role.Name, role.Color = r.Name, r.Color
+			}
+		}
+		msg.MentionRoles = append(msg.MentionRoles, role)
+	}
+
+	for _, match := range patternChannelMention.FindAllStringSubmatch(m.Content, -1) {
+		if seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+
+		c := Channel{ID: match[1]}
+		if e.s.StateEnabled && e.s.State != nil {
+			if sc, err := e.s.State.Channel(match[1]); err == nil {
+				c = *newChannel(sc)
+			}
+		}
+		msg.MentionChannels = append(msg.MentionChannels, c)
+	}
+
+	for _, a := range m.Attachments {
+		msg.Attachments = append(msg.Attachments, Attachment{
+			ID:          a.ID,
+			Filename:    a.Filename,
+			Description: a.Description,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+			URL:         a.URL,
+			ProxyURL:    a.ProxyURL,
+			Width:       a.Width,
+			Height:      a.Height,
+		})
+	}
+
+	for _, embed := range m.Embeds {
+		msg.Embeds = append(msg.Embeds, newEmbed(embed))
+	}
+
+	for _, r := range m.Reactions {
+		if r.Emoji == nil {
+			continue
+		}
+		msg.Reactions = append(msg.Reactions, Reaction{
+			Emoji: Emoji{ID: string(r.Emoji.ID), Name: r.Emoji.Name, Animated: r.Emoji.Animated},
+			Count: r.Count,
+		})
+	}
+
+	if ref := m.MessageReference; ref != nil {
+		msg.Reference = &Reference{MessageID: ref.MessageID, ChannelID: ref.ChannelID, GuildID: ref.GuildID}
+	}
+
+	return msg
+}
+
+// user converts a user, the nickname is taken from member or the State.
+func (e *exporter) user(guildID string, u *discordgo.User, member *discordgo.Member) User {
+	user := User{
+		ID:            u.ID,
+		Username:      u.Username,
+		Discriminator: u.Discriminator,
+		AvatarURL:     u.AvatarURL("64"),
+		Bot:           u.Bot,
+	}
+
+	if member == nil && guildID != "" && e.s.StateEnabled && e.s.State != nil {
+		member, _ = e.s.State.Member(guildID, u.ID)
+	}
+	if member != nil {
+		user.Nickname = member.Nick
+	}
+	return user
+}
+
+func newChannel(c *discordgo.Channel) *Channel {
+	return &Channel{
+		ID:       c.ID,
+		GuildID:  c.GuildID,
+		ParentID: c.ParentID,
+		Name:     c.Name,
+		Topic:    c.Topic,
+		Type:     int(c.Type),
+	}
+}
+
+func newEmbed(e *discordgo.MessageEmbed) Embed {
+	embed := Embed{
+		Title:       e.Title,
+		Description: e.Description,
+		URL:         e.URL,
+		Timestamp:   e.Timestamp,
+		Color:       e.Color,
+		Fields:      []EmbedField{},
+	}
+
+	if e.Author != nil {
+		embed.Author = &EmbedAuthor{Name: e.Author.Name, URL: e.Author.URL, IconURL: e.Author.IconURL}
+	}
+	if e.Footer != nil {
+		embed.Footer = &EmbedFooter{Text: e.Footer.Text, IconURL: e.Footer.IconURL}
+	}
+	if e.Image != nil {
+		embed.ImageURL = e.Image.URL
+	}
+	if e.Thumbnail != nil {
+		embed.ThumbnailURL = e.Thumbnail.URL
+	}
+	for _, f := range e.Fields {
+		embed.Fields = append(embed.Fields, EmbedField{Name: f.Name, Value: f.Value, Inline: f.Inline})
+	}
+	return embed
+}
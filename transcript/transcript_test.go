@@ -0,0 +1,163 @@
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/NilPointer-Software/discordgo"
+)
+
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newTestSession returns a session which requests a guild with the channel 10 and its thread 20.
+func newTestSession() (*discordgo.Session, func()) {
+	responses := map[string]string{
+		"/channels/10": `{"id": "10", "guild_id": "1", "name": "ticket-1", "topic": "Ticket **1**", "type": 0}`,
+		"/channels/10/messages": `[
+			{"id": "300", "channel_id": "10", "author": {"id": "2", "username": "helper", "discriminator": "0001"},
+			 "content": "Hi <@3>, see <#11> and ask <@&4>", "timestamp": "2021-06-01T12:01:00+00:00", "edited_timestamp": "2021-06-01T12:05:00+00:00",
+			 "mentions": [{"id": "3", "username": "customer", "discriminator": "0002"}], "mention_roles": ["4"],
+			 "message_reference": {"message_id": "200", "channel_id": "10"},
+			 "reactions": [{"count": 2, "emoji": {"id": null, "name": "👍"}}]},
+			{"id": "200", "channel_id": "10", "author": {"id": "3", "username": "customer", "discriminator": "0002"},
+			 "content": "It crashes:\n` + "```go\\npanic(<nil>)\\n```" + `", "timestamp": "2021-06-01T12:00:00+00:00",
+			 "attachments": [{"id": "5", "filename": "log.txt", "size": 2048, "url": "https://cdn.discordapp.com/log.txt"}],
+			 "embeds": [{"title": "Report", "color": 16711680, "fields": [{"name": "Version", "value": "1.0"}]}]}
+		]`,
+		"/channels/20/messages":                `[{"id": "400", "channel_id": "20", "author": {"id": "2", "username": "helper", "discriminator": "0001"}, "content": "In a thread", "timestamp": "2021-06-02T12:00:00+00:00"}]`,
+		"/channels/10/threads/active":          `{"threads": [{"id": "20", "guild_id": "1", "parent_id": "10", "name": "logs", "type": 11}, {"id": "21", "parent_id": "99", "type": 11}], "members": []}`,
+		"/channels/10/threads/archived/public": `{"threads": [], "members": [], "has_more": false}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[strings.TrimPrefix(r.URL.Path, "/api/v"+discordgo.APIVersion)]
+		if !ok {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code": 50001, "message": "Missing Access"}`))
+			return
+		}
+
+		// Only the first page has messages.
+		if r.URL.Query().Get("after") != "" && r.URL.Query().Get("after") != "0" {
+			body = "[]"
+		}
+		w.Write([]byte(body))
+	}))
+	target, _ := url.Parse(srv.URL)
+
+	s, _ := discordgo.New("Bot token")
+	s.Client = &http.Client{Transport: rewriteTransport{target}}
+
+	s.State.GuildAdd(&discordgo.Guild{
+		ID:    "1",
+		Roles: []*discordgo.Role{{ID: "4", Name: "Support", Color: 0x00ff00}},
+		Channels: []*discordgo.Channel{
+			{ID: "11", GuildID: "1", Name: "rules"},
+		},
+		Members: []*discordgo.Member{
+			{GuildID: "1", Nick: "Customer", User: &discordgo.User{ID: "3", Username: "customer"}},
+		},
+	})
+
+	return s, srv.Close
+}
+
+func TestExportJSON(t *testing.T) {
+	s, closeServer := newTestSession()
+	defer closeServer()
+
+	var buf bytes.Buffer
+	if err := Export(s, "10", NewJSONWriter(&buf), &Options{Threads: true}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	var doc struct {
+		Version int `json:"version"`
+		Channel struct {
+			Channel
+			Messages []*Message `json:"messages"`
+			Threads  []struct {
+				Channel
+				Messages []*Message `json:"messages"`
+			} `json:"threads"`
+		} `json:"channel"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+
+	if doc.Version != Version || doc.Channel.Name != "ticket-1" {
+		t.Errorf("unexpected header: %+v", doc)
+	}
+	if len(doc.Channel.Messages) != 2 || doc.Channel.Messages[0].ID != "200" {
+		t.Fatalf("expected 2 messages, oldest first, got %+v", doc.Channel.Messages)
+	}
+	if len(doc.Channel.Threads) != 1 || doc.Channel.Threads[0].ID != "20" || len(doc.Channel.Threads[0].Messages) != 1 {
+		t.Errorf("expected the thread 20 with one message, got %+v", doc.Channel.Threads)
+	}
+
+	reply := doc.Channel.Messages[1]
+	if reply.Reference == nil || reply.Reference.MessageID != "200" {
+		t.Errorf("expected a reply to 200, got %+v", reply.Reference)
+	}
+	if len(reply.Mentions) != 1 || reply.Mentions[0].Nickname != "Customer" {
+		t.Errorf("expected the nickname of the mentioned member, got %+v", reply.Mentions)
+	}
+	if len(reply.MentionRoles) != 1 || reply.MentionRoles[0].Name != "Support" {
+		t.Errorf("expected the mentioned role, got %+v", reply.MentionRoles)
+	}
+	if len(reply.MentionChannels) != 1 || reply.MentionChannels[0].Name != "rules" {
+		t.Errorf("expected the mentioned channel, got %+v", reply.MentionChannels)
+	}
+	if len(reply.Reactions) != 1 || reply.Reactions[0].Count != 2 {
+		t.Errorf("unexpected reactions %+v", reply.Reactions)
+	}
+
+	first := doc.Channel.Messages[0]
+	if len(first.Attachments) != 1 || first.Attachments[0].Size != 2048 || len(first.Embeds) != 1 {
+		t.Errorf("unexpected attachments %+v or embeds %+v", first.Attachments, first.Embeds)
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	s, closeServer := newTestSession()
+	defer closeServer()
+
+	var buf bytes.Buffer
+	if err := Export(s, "10", NewHTMLWriter(&buf), &Options{Threads: true}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	page := buf.String()
+
+	for _, want := range []string{
+		"<title>#ticket-1</title>",
+		"Ticket <strong>1</strong>",
+		`<span class="mention">@Customer</span>`,
+		`<span class="mention">#rules</span>`,
+		`<span class="mention">@Support</span>`,
+		`<pre><code class="language-go">panic(&lt;nil&gt;)`,
+		"replying to @Customer",
+		`title="2021-06-01 12:05">(edited)`,
+		"log.txt</a> (2.00 KB)",
+		`<section class="thread" id="thread-20">`,
+		"In a thread",
+		"</html>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected the transcript to contain %q", want)
+		}
+	}
+}
@@ -72,6 +72,7 @@ func (u *User) Mention() string {
 //    size:    The size of the user's avatar as a power of two
 //             if size is an empty string, no size parameter will
 //             be added to the URL.
+// The size isn't validated, see CDNUserAvatar for other formats and validated sizes.
 func (u *User) AvatarURL(size string) string {
 	var URL string
 	if u.Avatar == "" {
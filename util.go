@@ -1,17 +1,23 @@
 package discordgo
 
-import (
-	"strconv"
-	"time"
-)
+import "time"
 
 // SnowflakeTimestamp returns the creation time of a Snowflake ID relative to the creation of Discord.
+// See also Snowflake.Time.
 func SnowflakeTimestamp(ID string) (t time.Time, err error) {
-	i, err := strconv.ParseInt(ID, 10, 64)
+	s, err := ParseSnowflake(ID)
 	if err != nil {
 		return
 	}
-	timestamp := (i >> 22) + 1420070400000
-	t = time.Unix(timestamp/1000, 0)
+	t = s.Time()
 	return
 }
+
+// SnowflakeLess reports whether the Snowflake ID a is smaller, and so older,
+// than b, without parsing them. It can be used to sort objects by ID.
+func SnowflakeLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
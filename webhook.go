@@ -0,0 +1,145 @@
+// Discordgo - Discord bindings for Go
+// Available at https://github.com/bwmarrin/discordgo
+
+// Copyright 2015-2016 Bruce Marriner <bruce@sqls.net>.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains helpers for working with webhooks.
+
+package discordgo
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrInvalidWebhookURL is returned when a webhook URL can't be parsed.
+var ErrInvalidWebhookURL = errors.New("invalid webhook URL")
+
+var webhookPathRegex = regexp.MustCompile(`^/api(?:/v[0-9]+)?/webhooks/([0-9]+)/([A-Za-z0-9_.\-]+)/?$`)
+
+// ParseWebhookURL returns the ID and token of a webhook URL, like
+// "https://discord.com/api/webhooks/ID/TOKEN". URLs of discordapp.com, the
+// canary and ptb clients and URLs including an API version are accepted too.
+func ParseWebhookURL(webhookURL string) (webhookID, token string, err error) {
+	u, err := url.Parse(strings.TrimSpace(webhookURL))
+	if err != nil {
+		return "", "", ErrInvalidWebhookURL
+	}
+
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", "", ErrInvalidWebhookURL
+	}
+
+	host := strings.TrimPrefix(strings.TrimPrefix(u.Hostname(), "canary."), "ptb.")
+	if host != "discord.com" && host != "discordapp.com" {
+		return "", "", ErrInvalidWebhookURL
+	}
+
+	m := webhookPathRegex.FindStringSubmatch(u.Path)
+	if m == nil {
+		return "", "", ErrInvalidWebhookURL
+	}
+
+	return m[1], m[2], nil
+}
+
+// WebhookClient sends and manages messages through a webhook. It only needs
+// the webhook's ID and token, no bot token or gateway connection.
+type WebhookClient struct {
+	ID    string
+	Token string
+
+	// Session is used to send the requests. It has no token or State, its
+	// Client, Ratelimiter, RetryPolicy and RequestHooks can be changed.
+	Session *Session
+}
+
+// NewWebhookClient returns a WebhookClient for a webhook URL,
+// e.g. "https://discord.com/api/webhooks/ID/TOKEN".
+func NewWebhookClient(webhookURL string) (*WebhookClient, error) {
+	webhookID, token, err := ParseWebhookURL(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWebhookClientWithToken(webhookID, token), nil
+}
+
+// NewWebhookClientWithToken returns a WebhookClient for the webhook with the given ID and token.
+func NewWebhookClientWithToken(webhookID, token string) *WebhookClient {
+	return &WebhookClient{
+		ID:    webhookID,
+		Token: token,
+		Session: &Session{
+			Ratelimiter:     NewRatelimiter(),
+			MaxRestRetries:  3,
+			RetryPolicy:     NewRetryPolicy(),
+			InvalidRequests: NewInvalidRequestLimiter(),
+			Client:          &http.Client{Timeout: (20 * time.Second)},
+			UserAgent:       "DiscordBot (https://github.com/bwmarrin/discordgo, v" + VERSION + ")",
+		},
+	}
+}
+
+// Webhook returns the webhook.
+func (w *WebhookClient) Webhook(options ...RequestOption) (*Webhook, error) {
+	return w.Session.WebhookWithToken(w.ID, w.Token, options...)
+}
+
+// Execute sends a message through the webhook and returns it.
+// threadID : Sends the message to the thread with this ID in the webhook's channel, if not empty.
+// data     : The message to send, files are sent from data.Files.
+func (w *WebhookClient) Execute(threadID string, data *WebhookParams, options ...RequestOption) (*Message, error) {
+	return w.Session.WebhookExecute(w.ID, w.Token, true, threadID, data, options...)
+}
+
+// ExecuteComplex sends a message with components and allowed mentions through the webhook and returns it.
+// threadID        : Sends the message to the thread with this ID in the webhook's channel, if not empty.
+// data            : The message to send, files are sent from data.Files.
+// components      : The components of the message, they need a webhook owned by an application.
+// allowedMentions : The mentions which are parsed, replacing data.AllowedMentions. If nil, Discord's defaults are used.
+func (w *WebhookClient) ExecuteComplex(threadID string, data *WebhookParams, components []Component, allowedMentions *AllowMention, options ...RequestOption) (*Message, error) {
+	return w.Session.WebhookExecuteComplex(w.ID, w.Token, true, threadID, data, components, allowedMentions, options...)
+}
+
+// Message returns a message sent by the webhook.
+// messageID : The ID of the message.
+// threadID  : The ID of the thread the message is in, if any.
+func (w *WebhookClient) Message(messageID, threadID string, options ...RequestOption) (*Message, error) {
+	return w.Session.WebhookMessage(w.ID, w.Token, messageID, threadID, options...)
+}
+
+// Edit edits a message sent by the webhook.
+// messageID : The ID of the message.
+// threadID  : The ID of the thread the message is in, if any.
+// data      : The changes to the message, files are sent from data.Files.
+func (w *WebhookClient) Edit(messageID, threadID string, data *WebhookEdit, options ...RequestOption) (*Message, error) {
+	return w.Session.WebhookMessageEdit(w.ID, w.Token, messageID, threadID, data, options...)
+}
+
+// ExecuteSlack sends a Slack compatible payload through the webhook.
+// threadID : Sends the message to the thread with this ID in the webhook's channel, if not empty.
+func (w *WebhookClient) ExecuteSlack(threadID string, data *SlackWebhookParams, options ...RequestOption) (*Message, error) {
+	return w.Session.WebhookExecuteSlack(w.ID, w.Token, true, threadID, data, options...)
+}
+
+// ExecuteGitHub sends a GitHub compatible payload through the webhook.
+// threadID : Sends the message to the thread with this ID in the webhook's channel, if not empty.
+// event    : The GitHub event name, e.g. "push".
+// data     : A *GitHubWebhookPayload, or the payload as received from GitHub (e.g. a json.RawMessage).
+func (w *WebhookClient) ExecuteGitHub(threadID, event string, data interface{}, options ...RequestOption) error {
+	return w.Session.WebhookExecuteGitHub(w.ID, w.Token, threadID, event, data, options...)
+}
+
+// Delete deletes a message sent by the webhook.
+// messageID : The ID of the message.
+// threadID  : The ID of the thread the message is in, if any.
+func (w *WebhookClient) Delete(messageID, threadID string, options ...RequestOption) error {
+	return w.Session.WebhookMessageDelete(w.ID, w.Token, messageID, threadID, options...)
+}
@@ -0,0 +1,175 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestParseWebhookURL(t *testing.T) {
+	valid := []string{
+		"https://discord.com/api/webhooks/81384788765712384/abc-DEF_123",
+		"https://discordapp.com/api/webhooks/81384788765712384/abc-DEF_123/",
+		"https://canary.discord.com/api/v8/webhooks/81384788765712384/abc-DEF_123?wait=true",
+	}
+	for _, u := range valid {
+		id, token, err := ParseWebhookURL(u)
+		if err != nil {
+			t.Errorf("ParseWebhookURL(%q) returned error: %v", u, err)
+			continue
+		}
+		if id != "81384788765712384" || token != "abc-DEF_123" {
+			t.Errorf("ParseWebhookURL(%q) = %q, %q", u, id, token)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"https://example.com/api/webhooks/81384788765712384/abc",
+		"https://discord.com/api/webhooks/abc/def",
+		"https://discord.com/api/webhooks/81384788765712384",
+	}
+	for _, u := range invalid {
+		if _, _, err := ParseWebhookURL(u); err != ErrInvalidWebhookURL {
+			t.Errorf("ParseWebhookURL(%q) expected ErrInvalidWebhookURL, got %v", u, err)
+		}
+	}
+}
+
+// rewriteTransport sends all requests to a test server.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWebhookClient(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("unexpected Authorization header")
+		}
+		paths = append(paths, r.Method+" "+r.URL.RequestURI())
+
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(&Message{ID: "2", Content: "hello"})
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	w, err := NewWebhookClient("https://discord.com/api/webhooks/1/token")
+	if err != nil {
+		t.Fatalf("NewWebhookClient returned error: %v", err)
+	}
+	w.Session.Client = &http.Client{Transport: rewriteTransport{target}}
+
+	m, err := w.Execute("3", &WebhookParams{Content: "hello"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if m.ID != "2" {
+		t.Errorf("expected message 2, got %q", m.ID)
+	}
+
+	content := "edited"
+	if _, err = w.Edit(m.ID, "", &WebhookEdit{Content: &content}); err != nil {
+		t.Fatalf("Edit returned error: %v", err)
+	}
+	if err = w.Delete(m.ID, "3"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	expected := []string{
+		"POST /api/v8/webhooks/1/token?thread_id=3&wait=true",
+		"PATCH /api/v8/webhooks/1/token/messages/2",
+		"DELETE /api/v8/webhooks/1/token/messages/2?thread_id=3",
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expected request %q, got %q", expected[i], paths[i])
+		}
+	}
+}
+
+func TestWebhookClientCompatiblePayloads(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+" "+r.Header.Get("X-GitHub-Event"))
+		if r.URL.Path == "/api/v8/webhooks/1/token/github" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(&Message{ID: "2"})
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	w := NewWebhookClientWithToken("1", "token")
+	w.Session.Client = &http.Client{Transport: rewriteTransport{target}}
+
+	_, err := w.ExecuteSlack("", &SlackWebhookParams{
+		Text:        "deployed",
+		Attachments: []*SlackAttachment{{Title: "v1.2.3", Color: "#36a64f"}},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteSlack returned error: %v", err)
+	}
+
+	err = w.ExecuteGitHub("", "push", json.RawMessage(`{"ref":"refs/heads/main","commits":[]}`))
+	if err != nil {
+		t.Fatalf("ExecuteGitHub returned error: %v", err)
+	}
+
+	expected := []string{
+		"/api/v8/webhooks/1/token/slack ",
+		"/api/v8/webhooks/1/token/github push",
+	}
+	if len(requests) != len(expected) || requests[0] != expected[0] || requests[1] != expected[1] {
+		t.Errorf("expected requests %q, got %q", expected, requests)
+	}
+}
+
+func TestWebhookClientExecuteComplex(t *testing.T) {
+	var body struct {
+		Content         string          `json:"content"`
+		Components      []Component     `json:"components"`
+		AllowedMentions json.RawMessage `json:"allowed_mentions"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		json.NewEncoder(w).Encode(&Message{ID: "2"})
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	w := NewWebhookClientWithToken("1", "token")
+	w.Session.Client = &http.Client{Transport: rewriteTransport{target}}
+
+	row := []Component{{Type: ComponentTypeButton, Style: StyleLink, Label: "docs", URL: "https://example.com"}}
+	components := []Component{{Type: ComponentTypeActionRow, Components: &row}}
+	_, err := w.ExecuteComplex("", &WebhookParams{Content: "hello"}, components, &AllowMention{Users: []string{"3"}})
+	if err != nil {
+		t.Fatalf("ExecuteComplex returned error: %v", err)
+	}
+
+	if body.Content != "hello" || len(body.Components) != 1 {
+		t.Errorf("unexpected body %+v", body)
+	}
+	if string(body.AllowedMentions) != `{"parse":null,"roles":null,"users":["3"]}` {
+		t.Errorf("unexpected allowed mentions %s", body.AllowedMentions)
+	}
+}